@@ -0,0 +1,204 @@
+package application
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxAttachmentNameLength matches the size of the attachments.file_name column
+const maxAttachmentNameLength = 255
+
+// AttachmentLimits restricts what can be uploaded as an attachment
+type AttachmentLimits struct {
+	MaxSize      int64    // Largest accepted file in bytes
+	AllowedTypes []string // MIME types accepted, matched against the sniffed content type
+}
+
+// DefaultAttachmentLimits accepts logs, screenshots and common documents up to 10 MiB
+var DefaultAttachmentLimits = AttachmentLimits{
+	MaxSize: 10 << 20,
+	AllowedTypes: []string{
+		"text/plain", "text/csv", "application/json", "application/pdf",
+		"application/zip", "application/x-gzip",
+		"image/png", "image/jpeg", "image/gif", "image/webp",
+	},
+}
+
+type AttachmentService struct {
+	repo     domain.AttachmentRepository
+	taskRepo domain.TaskRepository
+	storage  domain.BlobStorage
+	limits   AttachmentLimits
+}
+
+func NewAttachmentService(repo domain.AttachmentRepository, taskRepo domain.TaskRepository, storage domain.BlobStorage, limits AttachmentLimits) *AttachmentService {
+	return &AttachmentService{repo: repo, taskRepo: taskRepo, storage: storage, limits: limits}
+}
+
+// UploadAttachment stores a file on a task. The content type is detected from
+// the content itself rather than trusted from the client.
+func (s *AttachmentService) UploadAttachment(taskID uint, fileName string, content io.Reader) (domain.Attachment, error) {
+	if _, err := s.taskRepo.FindByID(taskID); err != nil {
+		return domain.Attachment{}, err
+	}
+	fileName, err := normalizeAttachmentName(fileName)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	buffered := bufio.NewReaderSize(content, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.Attachment{}, err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !s.allowedType(contentType) {
+		return domain.Attachment{}, fmt.Errorf("%w: attachments of type %s are not allowed", ErrInvalidInput, contentType)
+	}
+
+	key, err := newStorageKey(taskID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	// Read one byte past the limit so oversized files can be told apart
+	counter := &countingReader{r: io.LimitReader(buffered, s.limits.MaxSize+1)}
+	if err := s.storage.Put(key, counter); err != nil {
+		return domain.Attachment{}, err
+	}
+	if counter.n > s.limits.MaxSize {
+		s.storage.Delete(key)
+		return domain.Attachment{}, fmt.Errorf("%w: attachments must be at most %d bytes", ErrTooLarge, s.limits.MaxSize)
+	}
+
+	attachment := domain.Attachment{
+		TaskID:      taskID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        counter.n,
+		StorageKey:  key,
+		CreatedAt:   time.Now(),
+	}
+	id, err := s.repo.Save(attachment)
+	if err != nil {
+		s.storage.Delete(key)
+		return domain.Attachment{}, err
+	}
+	attachment.ID = id
+	return attachment, nil
+}
+
+// GetAttachments retrieves the metadata of a task's attachments.
+func (s *AttachmentService) GetAttachments(taskID uint) ([]domain.Attachment, error) {
+	if _, err := s.taskRepo.FindByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTask(taskID)
+}
+
+// OpenAttachment retrieves an attachment of a task along with its content; the caller closes the content.
+func (s *AttachmentService) OpenAttachment(taskID, id uint) (domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getAttachment(taskID, id)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	content, err := s.storage.Open(attachment.StorageKey)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment and its content.
+func (s *AttachmentService) DeleteAttachment(taskID, id uint) error {
+	attachment, err := s.getAttachment(taskID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	return s.storage.Delete(attachment.StorageKey)
+}
+
+// DeleteTaskAttachments removes every attachment of a task and its files; it runs when the task is deleted.
+func (s *AttachmentService) DeleteTaskAttachments(taskID uint) error {
+	attachments, err := s.repo.FindByTask(taskID)
+	if err != nil {
+		return err
+	}
+	// Drop the rows first: a leftover file is harmless, a row without its file is not
+	if err := s.repo.DeleteByTask(taskID); err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := s.storage.Delete(attachment.StorageKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAttachment finds an attachment and checks that it belongs to the task
+func (s *AttachmentService) getAttachment(taskID, id uint) (domain.Attachment, error) {
+	attachment, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.TaskID != taskID {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) allowedType(contentType string) bool {
+	for _, allowed := range s.limits.AllowedTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAttachmentName strips any directories from an uploaded file name
+func normalizeAttachmentName(name string) (string, error) {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "", fmt.Errorf("%w: attachment file name must not be empty", ErrInvalidInput)
+	}
+	if len(name) > maxAttachmentNameLength {
+		return "", fmt.Errorf("%w: attachment file name must be at most %d characters", ErrInvalidInput, maxAttachmentNameLength)
+	}
+	return name, nil
+}
+
+// newStorageKey picks an unguessable blob key grouped by task
+func newStorageKey(taskID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(b)), nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
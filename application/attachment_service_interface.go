@@ -0,0 +1,15 @@
+package application
+
+import (
+	"io"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// AttachmentServiceInterface defines the methods for managing files attached to tasks.
+type AttachmentServiceInterface interface {
+	UploadAttachment(taskID uint, fileName string, content io.Reader) (domain.Attachment, error)
+	GetAttachments(taskID uint) ([]domain.Attachment, error)
+	OpenAttachment(taskID, id uint) (domain.Attachment, io.ReadCloser, error)
+	DeleteAttachment(taskID, id uint) error
+}
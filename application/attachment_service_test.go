@@ -0,0 +1,142 @@
+package application
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentRepository is a mock implementation of the AttachmentRepository interface
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Save(attachment domain.Attachment) (uint, error) {
+	args := m.Called(attachment)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByID(id uint) (domain.Attachment, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByTask(taskID uint) ([]domain.Attachment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) DeleteByTask(taskID uint) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+// MockBlobStorage is a mock implementation of the BlobStorage interface that drains what it is given
+type MockBlobStorage struct {
+	mock.Mock
+}
+
+func (m *MockBlobStorage) Put(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	args := m.Called(key, data)
+	return args.Error(0)
+}
+
+func (m *MockBlobStorage) Open(key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockBlobStorage) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func TestUploadAttachment(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockStorage := new(MockBlobStorage)
+	service := NewAttachmentService(mockRepo, mockTaskRepo, mockStorage, DefaultAttachmentLimits)
+
+	content := "2024-05-01 12:00:00 ERROR connection refused\n"
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockStorage.On("Put", mock.AnythingOfType("string"), []byte(content)).Return(nil)
+	mockRepo.On("Save", mock.Anything).Return(uint(3), nil)
+
+	result, err := service.UploadAttachment(1, "../../logs/server.log", strings.NewReader(content))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), result.ID)
+	assert.Equal(t, "server.log", result.FileName)
+	assert.Equal(t, "text/plain", result.ContentType)
+	assert.Equal(t, int64(len(content)), result.Size)
+	assert.True(t, strings.HasPrefix(result.StorageKey, "tasks/1/"))
+}
+
+func TestUploadAttachment_TypeNotAllowed(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockStorage := new(MockBlobStorage)
+	service := NewAttachmentService(mockRepo, mockTaskRepo, mockStorage, DefaultAttachmentLimits)
+
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	// A Windows executable is sniffed as application/octet-stream whatever its name says
+	_, err := service.UploadAttachment(1, "screenshot.png", bytes.NewReader([]byte{'M', 'Z', 0x90, 0x00, 0x03, 0x00}))
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything)
+}
+
+func TestUploadAttachment_TooLarge(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockStorage := new(MockBlobStorage)
+	limits := DefaultAttachmentLimits
+	limits.MaxSize = 8
+	service := NewAttachmentService(mockRepo, mockTaskRepo, mockStorage, limits)
+
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockStorage.On("Put", mock.Anything, mock.Anything).Return(nil)
+	mockStorage.On("Delete", mock.Anything).Return(nil)
+
+	_, err := service.UploadAttachment(1, "server.log", strings.NewReader("more than eight bytes"))
+
+	assert.ErrorIs(t, err, ErrTooLarge)
+	mockStorage.AssertNumberOfCalls(t, "Delete", 1)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestDeleteTaskAttachments(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	mockStorage := new(MockBlobStorage)
+	service := NewAttachmentService(mockRepo, new(MockTaskRepository), mockStorage, DefaultAttachmentLimits)
+
+	mockRepo.On("FindByTask", uint(1)).Return([]domain.Attachment{
+		{ID: 3, TaskID: 1, StorageKey: "tasks/1/a"},
+		{ID: 4, TaskID: 1, StorageKey: "tasks/1/b"},
+	}, nil)
+	mockRepo.On("DeleteByTask", uint(1)).Return(nil)
+	mockStorage.On("Delete", "tasks/1/a").Return(nil)
+	mockStorage.On("Delete", "tasks/1/b").Return(nil)
+
+	err := service.DeleteTaskAttachments(1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "DeleteByTask", uint(1))
+	mockStorage.AssertNumberOfCalls(t, "Delete", 2)
+}
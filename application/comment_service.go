@@ -0,0 +1,107 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxCommentAuthorLength matches the size of the comments.author column
+const maxCommentAuthorLength = 128
+
+type CommentService struct {
+	repo     domain.CommentRepository
+	taskRepo domain.TaskRepository
+}
+
+func NewCommentService(repo domain.CommentRepository, taskRepo domain.TaskRepository) *CommentService {
+	return &CommentService{repo: repo, taskRepo: taskRepo}
+}
+
+// AddComment posts a comment on a task.
+func (s *CommentService) AddComment(taskID uint, author, body string) (domain.Comment, error) {
+	if _, err := s.taskRepo.FindByID(taskID); err != nil {
+		return domain.Comment{}, err
+	}
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return domain.Comment{}, fmt.Errorf("%w: comment author must not be empty", ErrInvalidInput)
+	}
+	if len(author) > maxCommentAuthorLength {
+		return domain.Comment{}, fmt.Errorf("%w: comment author must be at most %d characters", ErrInvalidInput, maxCommentAuthorLength)
+	}
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	now := time.Now()
+	comment := domain.Comment{TaskID: taskID, Author: author, Body: body, CreatedAt: now, UpdatedAt: now}
+	id, err := s.repo.Save(comment)
+	comment.ID = id
+	return comment, err
+}
+
+// GetComments retrieves the comments of a task, oldest first.
+func (s *CommentService) GetComments(taskID uint) ([]domain.Comment, error) {
+	if _, err := s.taskRepo.FindByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTask(taskID)
+}
+
+// GetComment retrieves a single comment of a task.
+func (s *CommentService) GetComment(taskID, id uint) (domain.Comment, error) {
+	comment, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.TaskID != taskID {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// EditComment replaces the body of a comment and marks it as edited.
+func (s *CommentService) EditComment(taskID, id uint, body string) (domain.Comment, error) {
+	comment, err := s.GetComment(taskID, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	body, err = normalizeCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+
+	comment.Body = body
+	comment.Edited = true
+	comment.UpdatedAt = time.Now()
+	return s.repo.Update(comment)
+}
+
+// DeleteComment removes a comment from a task.
+func (s *CommentService) DeleteComment(taskID, id uint) error {
+	if _, err := s.GetComment(taskID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// DeleteTaskComments removes the whole thread of a task; it runs when the task is deleted.
+func (s *CommentService) DeleteTaskComments(taskID uint) error {
+	return s.repo.DeleteByTask(taskID)
+}
+
+// normalizeCommentBody trims a comment body and rejects empty ones
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("%w: comment body must not be empty", ErrInvalidInput)
+	}
+	return body, nil
+}
@@ -0,0 +1,12 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// CommentServiceInterface defines the methods for managing the comment threads of tasks.
+type CommentServiceInterface interface {
+	AddComment(taskID uint, author, body string) (domain.Comment, error)
+	GetComments(taskID uint) ([]domain.Comment, error)
+	GetComment(taskID, id uint) (domain.Comment, error)
+	EditComment(taskID, id uint, body string) (domain.Comment, error)
+	DeleteComment(taskID, id uint) error
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCommentRepository is a mock implementation of the CommentRepository interface
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Save(comment domain.Comment) (uint, error) {
+	args := m.Called(comment)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByID(id uint) (domain.Comment, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByTask(taskID uint) ([]domain.Comment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(comment domain.Comment) (domain.Comment, error) {
+	args := m.Called(comment)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) DeleteByTask(taskID uint) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+func TestAddComment(t *testing.T) {
+	mockRepo := new(MockCommentRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewCommentService(mockRepo, mockTaskRepo)
+
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("Save", mock.Anything).Return(uint(4), nil)
+
+	result, err := service.AddComment(1, " alice ", " Looks good ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), result.ID)
+	assert.Equal(t, "alice", result.Author)
+	assert.Equal(t, "Looks good", result.Body)
+	assert.False(t, result.Edited)
+}
+
+func TestEditComment_MarksEdited(t *testing.T) {
+	mockRepo := new(MockCommentRepository)
+	service := NewCommentService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByID", uint(4)).Return(domain.Comment{ID: 4, TaskID: 1, Author: "alice", Body: "Looks good"}, nil)
+	mockRepo.On("Update", mock.Anything).Return(domain.Comment{}, nil)
+
+	_, err := service.EditComment(1, 4, "Needs another pass")
+
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Update", mock.MatchedBy(func(c domain.Comment) bool {
+		return c.Body == "Needs another pass" && c.Edited && !c.UpdatedAt.IsZero()
+	}))
+}
+
+func TestEditComment_OtherTask(t *testing.T) {
+	mockRepo := new(MockCommentRepository)
+	service := NewCommentService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByID", uint(4)).Return(domain.Comment{ID: 4, TaskID: 2, Body: "Looks good"}, nil)
+
+	_, err := service.EditComment(1, 4, "Needs another pass")
+
+	assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestPurgeTask_RemovesComments(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	comments := NewCommentService(mockCommentRepo, mockRepo)
+	service := NewTaskService(mockRepo, WithDeleteHook(comments.DeleteTaskComments))
+
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindTrashed").Return([]domain.Task{}, nil)
+	mockCommentRepo.On("DeleteByTask", uint(1)).Return(nil)
+	mockRepo.On("Delete", uint(1)).Return(nil)
+
+	err := service.PurgeTask(1)
+
+	assert.NoError(t, err)
+	mockCommentRepo.AssertCalled(t, "DeleteByTask", uint(1))
+	mockRepo.AssertCalled(t, "Delete", uint(1))
+}
+
+func TestPurgeTask_CommentCleanupFails(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	comments := NewCommentService(mockCommentRepo, mockRepo)
+	service := NewTaskService(mockRepo, WithDeleteHook(comments.DeleteTaskComments))
+
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindTrashed").Return([]domain.Task{}, nil)
+	mockCommentRepo.On("DeleteByTask", uint(1)).Return(errors.New("connection lost"))
+
+	err := service.PurgeTask(1)
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
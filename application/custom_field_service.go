@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+const (
+	// maxFieldNameLength matches the size of the custom_fields.name column
+	maxFieldNameLength = 64
+
+	// maxFieldTextLength matches the size of the field_values.text_value column
+	maxFieldTextLength = 255
+)
+
+type CustomFieldService struct {
+	repo     domain.CustomFieldRepository
+	projects domain.ProjectRepository
+}
+
+func NewCustomFieldService(repo domain.CustomFieldRepository, projects domain.ProjectRepository) *CustomFieldService {
+	return &CustomFieldService{repo: repo, projects: projects}
+}
+
+// AddField defines a new custom field on a project. Field names are unique
+// within a project, ignoring case.
+func (s *CustomFieldService) AddField(projectID uint, input domain.CustomField) (domain.CustomField, error) {
+	if _, err := s.projects.FindByID(projectID); err != nil {
+		return domain.CustomField{}, err
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.CustomField{}, fmt.Errorf("%w: field name must not be empty", ErrInvalidInput)
+	}
+	if len(name) > maxFieldNameLength {
+		return domain.CustomField{}, fmt.Errorf("%w: field name must be at most %d characters", ErrInvalidInput, maxFieldNameLength)
+	}
+	if !input.Type.Valid() {
+		return domain.CustomField{}, fmt.Errorf("%w: unknown field type %q", ErrInvalidInput, input.Type)
+	}
+	options, err := normalizeFieldOptions(input.Type, input.Options)
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+
+	existing, err := s.repo.FindByProject(projectID)
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	for _, field := range existing {
+		if strings.EqualFold(field.Name, name) {
+			return domain.CustomField{}, fmt.Errorf("%w: project already has a field named %q", ErrConflict, field.Name)
+		}
+	}
+
+	field := domain.CustomField{ProjectID: projectID, Name: name, Type: input.Type, Options: options, CreatedAt: time.Now()}
+	id, err := s.repo.Save(field)
+	field.ID = id
+	return field, err
+}
+
+// GetFields retrieves the custom fields a project defines.
+func (s *CustomFieldService) GetFields(projectID uint) ([]domain.CustomField, error) {
+	if _, err := s.projects.FindByID(projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByProject(projectID)
+}
+
+// DeleteField removes a custom field from a project along with every task's value for it.
+func (s *CustomFieldService) DeleteField(projectID, fieldID uint) error {
+	field, err := s.repo.FindByID(fieldID)
+	if err != nil {
+		return err
+	}
+	if field.ProjectID != projectID {
+		return domain.ErrCustomFieldNotFound
+	}
+	return s.repo.Delete(fieldID)
+}
+
+// normalizeFieldOptions trims the options of an enum field and checks they are
+// usable; fields of other types take no options
+func normalizeFieldOptions(fieldType domain.FieldType, options []string) ([]string, error) {
+	if fieldType != domain.FieldEnum {
+		if len(options) > 0 {
+			return nil, fmt.Errorf("%w: only enum fields take options", ErrInvalidInput)
+		}
+		return nil, nil
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: an enum field needs at least one option", ErrInvalidInput)
+	}
+	seen := make(map[string]bool, len(options))
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "":
+			return nil, fmt.Errorf("%w: enum options must not be empty", ErrInvalidInput)
+		case len(option) > maxFieldTextLength:
+			return nil, fmt.Errorf("%w: enum options must be at most %d characters", ErrInvalidInput, maxFieldTextLength)
+		case seen[option]:
+			return nil, fmt.Errorf("%w: duplicate enum option %q", ErrInvalidInput, option)
+		}
+		seen[option] = true
+		normalized = append(normalized, option)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,10 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// CustomFieldServiceInterface defines the methods for managing the custom fields of projects.
+type CustomFieldServiceInterface interface {
+	AddField(projectID uint, field domain.CustomField) (domain.CustomField, error)
+	GetFields(projectID uint) ([]domain.CustomField, error)
+	DeleteField(projectID, fieldID uint) error
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCustomFieldRepository is a mock implementation of the CustomFieldRepository interface
+type MockCustomFieldRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomFieldRepository) Save(field domain.CustomField) (uint, error) {
+	args := m.Called(field)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) FindByID(id uint) (domain.CustomField, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) FindByProject(projectID uint) ([]domain.CustomField, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]domain.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestAddField_DuplicateName(t *testing.T) {
+	mockRepo := new(MockCustomFieldRepository)
+	mockProjects := new(MockProjectRepository)
+	service := NewCustomFieldService(mockRepo, mockProjects)
+
+	mockProjects.On("FindByID", uint(1)).Return(domain.Project{ID: 1}, nil)
+	mockRepo.On("FindByProject", uint(1)).Return([]domain.CustomField{{ID: 3, ProjectID: 1, Name: "Points", Type: domain.FieldNumber}}, nil)
+
+	_, err := service.AddField(1, domain.CustomField{Name: " points ", Type: domain.FieldText})
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAddField_EnumNeedsOptions(t *testing.T) {
+	mockRepo := new(MockCustomFieldRepository)
+	mockProjects := new(MockProjectRepository)
+	service := NewCustomFieldService(mockRepo, mockProjects)
+
+	mockProjects.On("FindByID", uint(1)).Return(domain.Project{ID: 1}, nil)
+
+	_, err := service.AddField(1, domain.CustomField{Name: "Stage", Type: domain.FieldEnum})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAddField_TrimsOptions(t *testing.T) {
+	mockRepo := new(MockCustomFieldRepository)
+	mockProjects := new(MockProjectRepository)
+	service := NewCustomFieldService(mockRepo, mockProjects)
+
+	mockProjects.On("FindByID", uint(1)).Return(domain.Project{ID: 1}, nil)
+	mockRepo.On("FindByProject", uint(1)).Return([]domain.CustomField{}, nil)
+	mockRepo.On("Save", mock.MatchedBy(func(field domain.CustomField) bool {
+		return field.Name == "Stage" && len(field.Options) == 2 && field.Options[0] == "draft" && field.Options[1] == "final"
+	})).Return(uint(4), nil)
+
+	field, err := service.AddField(1, domain.CustomField{Name: " Stage", Type: domain.FieldEnum, Options: []string{" draft", "final "}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), field.ID)
+}
+
+func TestDeleteField_OtherProject(t *testing.T) {
+	mockRepo := new(MockCustomFieldRepository)
+	service := NewCustomFieldService(mockRepo, new(MockProjectRepository))
+
+	mockRepo.On("FindByID", uint(3)).Return(domain.CustomField{ID: 3, ProjectID: 2}, nil)
+
+	err := service.DeleteField(1, 3)
+
+	assert.ErrorIs(t, err, domain.ErrCustomFieldNotFound)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
@@ -0,0 +1,14 @@
+package application
+
+import "errors"
+
+var (
+	// ErrInvalidInput is returned when a request carries values the service cannot accept
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrConflict is returned when a request is valid but clashes with the current state
+	ErrConflict = errors.New("conflict")
+
+	// ErrTooLarge is returned when an upload exceeds the configured size limit
+	ErrTooLarge = errors.New("too large")
+)
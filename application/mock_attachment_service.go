@@ -0,0 +1,34 @@
+package application
+
+import (
+	"io"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) UploadAttachment(taskID uint, fileName string, content io.Reader) (domain.Attachment, error) {
+	args := m.Called(taskID, fileName, content)
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) GetAttachments(taskID uint) ([]domain.Attachment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) OpenAttachment(taskID, id uint) (domain.Attachment, io.ReadCloser, error) {
+	args := m.Called(taskID, id)
+	content, _ := args.Get(1).(io.ReadCloser)
+	return args.Get(0).(domain.Attachment), content, args.Error(2)
+}
+
+func (m *MockAttachmentService) DeleteAttachment(taskID, id uint) error {
+	args := m.Called(taskID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) AddComment(taskID uint, author, body string) (domain.Comment, error) {
+	args := m.Called(taskID, author, body)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) GetComments(taskID uint) ([]domain.Comment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) GetComment(taskID, id uint) (domain.Comment, error) {
+	args := m.Called(taskID, id)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) EditComment(taskID, id uint, body string) (domain.Comment, error) {
+	args := m.Called(taskID, id, body)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) DeleteComment(taskID, id uint) error {
+	args := m.Called(taskID, id)
+	return args.Error(0)
+}
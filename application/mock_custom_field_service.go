@@ -0,0 +1,26 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCustomFieldService struct {
+	mock.Mock
+}
+
+func (m *MockCustomFieldService) AddField(projectID uint, field domain.CustomField) (domain.CustomField, error) {
+	args := m.Called(projectID, field)
+	return args.Get(0).(domain.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldService) GetFields(projectID uint) ([]domain.CustomField, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]domain.CustomField), args.Error(1)
+}
+
+func (m *MockCustomFieldService) DeleteField(projectID, fieldID uint) error {
+	args := m.Called(projectID, fieldID)
+	return args.Error(0)
+}
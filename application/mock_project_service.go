@@ -0,0 +1,50 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProjectService struct {
+	mock.Mock
+}
+
+func (m *MockProjectService) CreateProject(name, description string) (domain.Project, error) {
+	args := m.Called(name, description)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectService) GetAllProjects() ([]domain.Project, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockProjectService) GetProject(id uint) (domain.Project, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectService) UpdateProject(id uint, project domain.Project) (domain.Project, error) {
+	args := m.Called(id, project)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectService) DeleteProject(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockProjectService) GetProjectTasks(id uint) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockProjectService) MoveTaskToProject(taskID uint, projectID *uint) (domain.Task, error) {
+	args := m.Called(taskID, projectID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockProjectService) As(actor *uint) ProjectServiceInterface {
+	return m
+}
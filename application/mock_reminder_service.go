@@ -0,0 +1,26 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReminderService struct {
+	mock.Mock
+}
+
+func (m *MockReminderService) AddReminder(taskID uint, reminder domain.Reminder) (domain.Reminder, error) {
+	args := m.Called(taskID, reminder)
+	return args.Get(0).(domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderService) GetReminders(taskID uint) ([]domain.Reminder, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderService) DeleteReminder(taskID, id uint) error {
+	args := m.Called(taskID, id)
+	return args.Error(0)
+}
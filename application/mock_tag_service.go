@@ -0,0 +1,50 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) CreateTag(name string) (domain.Tag, error) {
+	args := m.Called(name)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagService) GetAllTags() ([]domain.Tag, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+func (m *MockTagService) GetTag(id uint) (domain.Tag, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagService) RenameTag(id uint, name string) (domain.Tag, error) {
+	args := m.Called(id, name)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagService) DeleteTag(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTagService) TagTask(taskID uint, name string) (domain.Task, error) {
+	args := m.Called(taskID, name)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTagService) UntagTask(taskID, tagID uint) (domain.Task, error) {
+	args := m.Called(taskID, tagID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTagService) As(actor *uint) TagServiceInterface {
+	return m
+}
@@ -1,6 +1,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/krishnakumarkp/to-do/domain"
 
 	"github.com/stretchr/testify/mock"
@@ -10,8 +12,8 @@ type MockTaskService struct {
 	mock.Mock
 }
 
-func (m *MockTaskService) CreateTask(title, description string) (domain.Task, error) {
-	args := m.Called(title, description)
+func (m *MockTaskService) CreateTask(task domain.Task) (domain.Task, error) {
+	args := m.Called(task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
@@ -20,6 +22,36 @@ func (m *MockTaskService) GetAllTasks() ([]domain.Task, error) {
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) QueryTasks(query domain.TaskQuery) (domain.TaskPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(domain.TaskPage), args.Error(1)
+}
+
+func (m *MockTaskService) SearchTasks(query string, limit int) ([]domain.SearchHit, error) {
+	args := m.Called(query, limit)
+	return args.Get(0).([]domain.SearchHit), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	args := m.Called(names, matchAll)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetOverdueTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksDueToday() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksDueWithin(days int) ([]domain.Task, error) {
+	args := m.Called(days)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
 func (m *MockTaskService) GetTask(id uint) (domain.Task, error) {
 	args := m.Called(id)
 	return args.Get(0).(domain.Task), args.Error(1)
@@ -30,12 +62,202 @@ func (m *MockTaskService) UpdateTask(id uint, task domain.Task) (domain.Task, er
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskService) MarkTaskCompleted(id uint) (domain.Task, error) {
+func (m *MockTaskService) MarkTaskCompleted(id uint, force bool) (domain.Task, error) {
+	args := m.Called(id, force)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetTaskStatus(id uint, status domain.Status) (domain.Task, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetSubtasks(id uint) ([]domain.Task, error) {
 	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetParent(id uint, parentID *uint) (domain.Task, error) {
+	args := m.Called(id, parentID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetSeries(id uint) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetRecurrence(id uint, rule string) (domain.Task, error) {
+	args := m.Called(id, rule)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetBlockers(id uint) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetReadyTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) AddBlocker(id, blockerID uint) ([]domain.Task, error) {
+	args := m.Called(id, blockerID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RemoveBlocker(id, blockerID uint) error {
+	args := m.Called(id, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) AddChecklistItem(taskID uint, text string) (domain.Task, error) {
+	args := m.Called(taskID, text)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) MoveChecklistItem(taskID, itemID uint, position int) (domain.Task, error) {
+	args := m.Called(taskID, itemID, position)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ToggleChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	args := m.Called(taskID, itemID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RemoveChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	args := m.Called(taskID, itemID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) AssignTask(taskID, userID uint) (domain.Task, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UnassignTask(taskID uint) (domain.Task, error) {
+	args := m.Called(taskID)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) GetTasksByAssignee(userID uint) ([]domain.Task, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetUnassignedTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) StartTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) StopTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) AddTimeEntry(taskID uint, entry domain.TimeEntry) (domain.TimeEntry, error) {
+	args := m.Called(taskID, entry)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) GetTimeEntries(taskID uint) ([]domain.TimeEntry, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) DeleteTimeEntry(taskID, entryID uint) error {
+	args := m.Called(taskID, entryID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GetTaskTimeTotals(taskID uint) (domain.TimeTotals, error) {
+	args := m.Called(taskID)
+	return args.Get(0).(domain.TimeTotals), args.Error(1)
+}
+
+func (m *MockTaskService) GetProjectTimeTotals(projectID uint) (domain.TimeTotals, error) {
+	args := m.Called(projectID)
+	return args.Get(0).(domain.TimeTotals), args.Error(1)
+}
+
 func (m *MockTaskService) DeleteTask(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockTaskService) SetFieldValue(taskID, fieldID uint, value any) (domain.Task, error) {
+	args := m.Called(taskID, fieldID, value)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ClearFieldValue(taskID, fieldID uint) (domain.Task, error) {
+	args := m.Called(taskID, fieldID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksByFieldValues(filters map[uint]string) ([]domain.Task, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ReorderTask(id uint, move domain.TaskMove) (domain.Task, error) {
+	args := m.Called(id, move)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ArchiveTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UnarchiveTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ArchiveCompletedBefore(before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskService) GetArchivedTasks(query string) ([]domain.Task, error) {
+	args := m.Called(query)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTrash() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RestoreTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) PurgeTask(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GetTaskHistory(id uint) ([]domain.Revision, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Revision), args.Error(1)
+}
+
+func (m *MockTaskService) RevertTask(id, revisionID uint) (domain.Task, error) {
+	args := m.Called(id, revisionID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+// As returns the mock itself, so expectations hold whichever user a handler acts as
+func (m *MockTaskService) As(actor *uint) TaskServiceInterface {
+	return m
+}
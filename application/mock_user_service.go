@@ -0,0 +1,31 @@
+package application
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) CreateUser(name, email string) (domain.User, error) {
+	args := m.Called(name, email)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserService) GetAllUsers() ([]domain.User, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserService) GetUser(id uint) (domain.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserService) DeleteUser(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
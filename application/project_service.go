@@ -0,0 +1,213 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxProjectNameLength matches the size of the projects.name column
+const maxProjectNameLength = 128
+
+type ProjectService struct {
+	repo     domain.ProjectRepository
+	taskRepo domain.TaskRepository
+	taskRecorder
+}
+
+// ProjectServiceOption configures optional collaborators of a ProjectService
+type ProjectServiceOption func(*ProjectService)
+
+// WithProjectRevisions records a revision of every task moved between projects
+func WithProjectRevisions(revisions domain.RevisionRepository) ProjectServiceOption {
+	return func(s *ProjectService) {
+		s.revisions = revisions
+	}
+}
+
+func NewProjectService(repo domain.ProjectRepository, taskRepo domain.TaskRepository, opts ...ProjectServiceOption) *ProjectService {
+	s := &ProjectService{repo: repo, taskRepo: taskRepo, taskRecorder: taskRecorder{now: time.Now}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// As returns a copy of the service whose revisions are attributed to the
+// given user; a nil actor records changes without one.
+func (s *ProjectService) As(actor *uint) ProjectServiceInterface {
+	scoped := *s
+	scoped.actor = actor
+	return &scoped
+}
+
+// CreateProject creates a new, empty project.
+func (s *ProjectService) CreateProject(name, description string) (domain.Project, error) {
+	name, err := normalizeProjectName(name)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	project := domain.Project{Name: name, Description: description, CreatedAt: time.Now()}
+	id, err := s.repo.Save(project)
+	project.ID = id
+	return project, err
+}
+
+// GetAllProjects retrieves all projects along with their task counts.
+func (s *ProjectService) GetAllProjects() ([]domain.Project, error) {
+	projects, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.taskRepo.CountByProject()
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		projects[i].TaskCount = counts[projects[i].ID].Total
+		projects[i].OpenTaskCount = counts[projects[i].ID].Open
+	}
+	return projects, nil
+}
+
+// GetProject retrieves a project by its ID along with its task counts.
+func (s *ProjectService) GetProject(id uint) (domain.Project, error) {
+	project, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	counts, err := s.taskRepo.CountByProject()
+	if err != nil {
+		return domain.Project{}, err
+	}
+	project.TaskCount = counts[id].Total
+	project.OpenTaskCount = counts[id].Open
+	return project, nil
+}
+
+// UpdateProject changes a project's name and description.
+func (s *ProjectService) UpdateProject(id uint, input domain.Project) (domain.Project, error) {
+	project, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	name, err := normalizeProjectName(input.Name)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	project.Name = name
+	project.Description = input.Description
+	return s.repo.Update(project)
+}
+
+// DeleteProject removes a project. Projects that still own tasks cannot be
+// deleted; tasks of the project in the trash are taken out of it instead, so
+// none is left pointing at a missing project.
+func (s *ProjectService) DeleteProject(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	counts, err := s.taskRepo.CountByProject()
+	if err != nil {
+		return err
+	}
+	if n := counts[id].Total; n > 0 {
+		return fmt.Errorf("%w: project still has %d tasks", ErrConflict, n)
+	}
+	trashed, err := s.taskRepo.FindTrashed()
+	if err != nil {
+		return err
+	}
+	for _, task := range trashed {
+		if task.ProjectID == nil || *task.ProjectID != id {
+			continue
+		}
+		previous := task
+		placeInProject(&task, nil)
+		if _, err := s.saveChange(s.taskRepo, previous, task, domain.RevisionUpdate); err != nil {
+			return err
+		}
+	}
+	return s.repo.Delete(id)
+}
+
+// GetProjectTasks retrieves the tasks belonging to a project.
+func (s *ProjectService) GetProjectTasks(id uint) ([]domain.Task, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.taskRepo.FindByProject(id)
+}
+
+// MoveTaskToProject moves a task and all of its subtasks into a project,
+// or out of any project when projectID is nil.
+func (s *ProjectService) MoveTaskToProject(taskID uint, projectID *uint) (domain.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkProject(s.repo, projectID); err != nil {
+		return domain.Task{}, err
+	}
+
+	// Subtasks move along with their parent
+	queue := []uint{taskID}
+	for len(queue) > 0 {
+		children, err := s.taskRepo.FindChildren(queue[0])
+		if err != nil {
+			return domain.Task{}, err
+		}
+		queue = queue[1:]
+		for _, child := range children {
+			previous := child
+			placeInProject(&child, projectID)
+			if _, err := s.saveChange(s.taskRepo, previous, child, domain.RevisionUpdate); err != nil {
+				return domain.Task{}, err
+			}
+			queue = append(queue, child.ID)
+		}
+	}
+
+	previous := task
+	placeInProject(&task, projectID)
+	return s.saveChange(s.taskRepo, previous, task, domain.RevisionUpdate)
+}
+
+// placeInProject moves a task into a project, dropping its values for the
+// custom fields of the project it leaves
+func placeInProject(task *domain.Task, projectID *uint) {
+	if task.ProjectID == nil || projectID == nil || *task.ProjectID != *projectID {
+		task.FieldValues = nil
+	}
+	task.ProjectID = projectID
+}
+
+// checkProject verifies that a task may be placed in the given project
+func checkProject(repo domain.ProjectRepository, projectID *uint) error {
+	if projectID == nil {
+		return nil
+	}
+	if _, err := repo.FindByID(*projectID); errors.Is(err, domain.ErrProjectNotFound) {
+		return fmt.Errorf("%w: project %d does not exist", ErrInvalidInput, *projectID)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeProjectName trims a project name and checks it fits the schema
+func normalizeProjectName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: project name must not be empty", ErrInvalidInput)
+	}
+	if len(name) > maxProjectNameLength {
+		return "", fmt.Errorf("%w: project name must be at most %d characters", ErrInvalidInput, maxProjectNameLength)
+	}
+	return name, nil
+}
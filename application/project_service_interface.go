@@ -0,0 +1,16 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// ProjectServiceInterface defines the methods for managing projects and the tasks they own.
+type ProjectServiceInterface interface {
+	CreateProject(name, description string) (domain.Project, error)
+	GetAllProjects() ([]domain.Project, error)
+	GetProject(id uint) (domain.Project, error)
+	UpdateProject(id uint, project domain.Project) (domain.Project, error)
+	DeleteProject(id uint) error
+	GetProjectTasks(id uint) ([]domain.Task, error)
+	MoveTaskToProject(taskID uint, projectID *uint) (domain.Task, error)
+	// As scopes the service to a user, who is recorded as the actor of the task changes it makes
+	As(actor *uint) ProjectServiceInterface
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectRepository is a mock implementation of the ProjectRepository interface
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Save(project domain.Project) (uint, error) {
+	args := m.Called(project)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByID(id uint) (domain.Project, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindAll() ([]domain.Project, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(project domain.Project) (domain.Project, error) {
+	args := m.Called(project)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestGetAllProjects_Counts(t *testing.T) {
+	mockRepo := new(MockProjectRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewProjectService(mockRepo, mockTaskRepo)
+
+	mockRepo.On("FindAll").Return([]domain.Project{{ID: 1, Name: "Home"}, {ID: 2, Name: "Work"}}, nil)
+	mockTaskRepo.On("CountByProject").Return(map[uint]domain.TaskCounts{1: {Total: 3, Open: 1}}, nil)
+
+	projects, err := service.GetAllProjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), projects[0].TaskCount)
+	assert.Equal(t, int64(1), projects[0].OpenTaskCount)
+	assert.Equal(t, int64(0), projects[1].TaskCount)
+}
+
+func TestDeleteProject_HasTasks(t *testing.T) {
+	mockRepo := new(MockProjectRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewProjectService(mockRepo, mockTaskRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Project{ID: 1, Name: "Home"}, nil)
+	mockTaskRepo.On("CountByProject").Return(map[uint]domain.TaskCounts{1: {Total: 2}}, nil)
+
+	err := service.DeleteProject(1)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDeleteProject_DetachesTrashedTasks(t *testing.T) {
+	mockRepo := new(MockProjectRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewProjectService(mockRepo, mockTaskRepo)
+
+	projectID, otherID := uint(1), uint(2)
+	mockRepo.On("FindByID", projectID).Return(domain.Project{ID: projectID, Name: "Home"}, nil)
+	mockTaskRepo.On("CountByProject").Return(map[uint]domain.TaskCounts{otherID: {Total: 1}}, nil)
+	mockTaskRepo.On("FindTrashed").Return([]domain.Task{{ID: 3, ProjectID: &projectID}, {ID: 4, ProjectID: &otherID}, {ID: 5}}, nil)
+	mockTaskRepo.On("Update", domain.Task{ID: 3}).Return(domain.Task{ID: 3}, nil)
+	mockRepo.On("Delete", projectID).Return(nil)
+
+	err := service.DeleteProject(projectID)
+
+	assert.NoError(t, err)
+	mockTaskRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockRepo.AssertCalled(t, "Delete", projectID)
+}
+
+func TestMoveTaskToProject_MovesSubtasks(t *testing.T) {
+	mockRepo := new(MockProjectRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewProjectService(mockRepo, mockTaskRepo)
+
+	projectID := uint(5)
+	parentID := uint(1)
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindByID", projectID).Return(domain.Project{ID: projectID}, nil)
+	mockTaskRepo.On("FindChildren", uint(1)).Return([]domain.Task{{ID: 2, ParentID: &parentID}}, nil)
+	mockTaskRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockTaskRepo.On("Update", domain.Task{ID: 2, ParentID: &parentID, ProjectID: &projectID}).Return(domain.Task{ID: 2}, nil)
+	mockTaskRepo.On("Update", domain.Task{ID: 1, ProjectID: &projectID}).Return(domain.Task{ID: 1, ProjectID: &projectID}, nil)
+
+	result, err := service.MoveTaskToProject(1, &projectID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &projectID, result.ProjectID)
+	mockTaskRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestMoveTaskToProject_UnknownProject(t *testing.T) {
+	mockRepo := new(MockProjectRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewProjectService(mockRepo, mockTaskRepo)
+
+	projectID := uint(9)
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindByID", projectID).Return(domain.Project{}, domain.ErrProjectNotFound)
+
+	_, err := service.MoveTaskToProject(1, &projectID)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
@@ -0,0 +1,221 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+const (
+	// maxReminderOffset is the furthest before a due date a reminder can fire
+	maxReminderOffset = 365 * 24 * 60
+
+	// maxReminderAttempts is how often delivering a reminder is tried before giving up
+	maxReminderAttempts = 5
+
+	// reminderRetryDelay is the wait before the first retry; it doubles with every failure
+	reminderRetryDelay = time.Minute
+
+	// reminderDeliveryTimeout bounds a single call to the notifier
+	reminderDeliveryTimeout = 30 * time.Second
+
+	// maxReminderErrorLength matches the size of the reminders.last_error column
+	maxReminderErrorLength = 255
+)
+
+type ReminderService struct {
+	repo     domain.ReminderRepository
+	taskRepo domain.TaskRepository
+	users    domain.UserRepository // Optional; resolves the assignee a reminder is addressed to
+	notifier domain.Notifier
+	now      func() time.Time
+}
+
+func NewReminderService(repo domain.ReminderRepository, taskRepo domain.TaskRepository, users domain.UserRepository, notifier domain.Notifier) *ReminderService {
+	return &ReminderService{repo: repo, taskRepo: taskRepo, users: users, notifier: notifier, now: time.Now}
+}
+
+// AddReminder sets a reminder on a task, either at an absolute time or at an
+// offset in minutes before the task's due date.
+func (s *ReminderService) AddReminder(taskID uint, input domain.Reminder) (domain.Reminder, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		return domain.Reminder{}, err
+	}
+	now := s.now()
+	switch {
+	case (input.RemindAt == nil) == (input.OffsetMinutes == nil):
+		return domain.Reminder{}, fmt.Errorf("%w: reminder needs either remind_at or offset_minutes", ErrInvalidInput)
+	case input.RemindAt != nil && !input.RemindAt.After(now):
+		return domain.Reminder{}, fmt.Errorf("%w: remind_at must be in the future", ErrInvalidInput)
+	case input.OffsetMinutes != nil && (*input.OffsetMinutes < 0 || *input.OffsetMinutes > maxReminderOffset):
+		return domain.Reminder{}, fmt.Errorf("%w: offset_minutes must be between 0 and %d", ErrInvalidInput, maxReminderOffset)
+	}
+
+	reminder := domain.Reminder{
+		TaskID:        taskID,
+		RemindAt:      input.RemindAt,
+		OffsetMinutes: input.OffsetMinutes,
+		Status:        domain.ReminderPending,
+		CreatedAt:     now,
+	}
+	reminder.FireAt = reminder.Schedule(task.DueDate)
+	id, err := s.repo.Save(reminder)
+	reminder.ID = id
+	return reminder, err
+}
+
+// GetReminders retrieves the reminders of a task.
+func (s *ReminderService) GetReminders(taskID uint) ([]domain.Reminder, error) {
+	if _, err := s.taskRepo.FindByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByTask(taskID)
+}
+
+// DeleteReminder removes a reminder from a task.
+func (s *ReminderService) DeleteReminder(taskID, id uint) error {
+	reminder, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if reminder.TaskID != taskID {
+		return domain.ErrReminderNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+// DeleteTaskReminders removes every reminder of a task. It is meant to be
+// registered as a TaskService delete hook.
+func (s *ReminderService) DeleteTaskReminders(taskID uint) error {
+	return s.repo.DeleteByTask(taskID)
+}
+
+// RescheduleTask moves the offset reminders of a task after its due date
+// changed. Reminders that already fired are re-armed when their new fire time
+// is still ahead. It is meant to be registered as a TaskService due date hook.
+func (s *ReminderService) RescheduleTask(task domain.Task) error {
+	reminders, err := s.repo.FindByTask(task.ID)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, reminder := range reminders {
+		if reminder.OffsetMinutes == nil {
+			continue
+		}
+		fireAt := reminder.Schedule(task.DueDate)
+		if reminder.Status != domain.ReminderPending {
+			if fireAt == nil || !fireAt.After(now) {
+				continue
+			}
+			reminder.Status = domain.ReminderPending
+			reminder.SentAt = nil
+		}
+		reminder.FireAt = fireAt
+		reminder.Attempts = 0
+		reminder.LastError = ""
+		if _, err := s.repo.Update(reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FireDue delivers every pending reminder whose time has come and reports how
+// many were delivered. Failed deliveries are retried with a growing delay.
+func (s *ReminderService) FireDue(ctx context.Context) (int, error) {
+	reminders, err := s.repo.FindDue(s.now())
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, reminder := range reminders {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		delivered, err := s.fire(ctx, reminder)
+		if err != nil {
+			return sent, err
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// fire delivers a single reminder and records the outcome
+func (s *ReminderService) fire(ctx context.Context, reminder domain.Reminder) (bool, error) {
+	task, err := s.taskRepo.FindByID(reminder.TaskID)
+	if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+		return false, err
+	}
+	// A task in the trash is not reminded about; the reminder is kept so it
+	// comes back with the task and is removed when the task is purged
+	if errors.Is(err, domain.ErrTaskNotFound) || task.Completed {
+		reminder.Status = domain.ReminderSkipped
+		_, err := s.repo.Update(reminder)
+		return false, err
+	}
+
+	notification := domain.Notification{Reminder: reminder, Task: task}
+	if s.users != nil && task.AssigneeID != nil {
+		if user, err := s.users.FindByID(*task.AssigneeID); err == nil {
+			notification.Recipient = &user
+		} else if !errors.Is(err, domain.ErrUserNotFound) {
+			return false, err
+		}
+	}
+
+	deliveryCtx, cancel := context.WithTimeout(ctx, reminderDeliveryTimeout)
+	err = s.notifier.Notify(deliveryCtx, notification)
+	cancel()
+	if err != nil && ctx.Err() != nil {
+		// Shutting down; the reminder stays pending and is retried on the next start
+		return false, ctx.Err()
+	}
+
+	now := s.now()
+	if err != nil {
+		reminder.Attempts++
+		reminder.LastError = err.Error()
+		if len(reminder.LastError) > maxReminderErrorLength {
+			reminder.LastError = reminder.LastError[:maxReminderErrorLength]
+		}
+		if reminder.Attempts >= maxReminderAttempts {
+			reminder.Status = domain.ReminderFailed
+		} else {
+			retryAt := now.Add(reminderRetryDelay << (reminder.Attempts - 1))
+			reminder.FireAt = &retryAt
+		}
+		_, err := s.repo.Update(reminder)
+		return false, err
+	}
+
+	reminder.Status = domain.ReminderSent
+	reminder.SentAt = &now
+	_, err = s.repo.Update(reminder)
+	return err == nil, err
+}
+
+// Run fires due reminders every interval until ctx is cancelled. Reminders
+// that came due while the server was down are fired right away on start.
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.FireDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to fire reminders: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// ReminderServiceInterface defines the methods for managing the reminders set on tasks.
+type ReminderServiceInterface interface {
+	AddReminder(taskID uint, reminder domain.Reminder) (domain.Reminder, error)
+	GetReminders(taskID uint) ([]domain.Reminder, error)
+	DeleteReminder(taskID, id uint) error
+}
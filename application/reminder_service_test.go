@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReminderRepository is a mock implementation of the ReminderRepository interface
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) Save(reminder domain.Reminder) (uint, error) {
+	args := m.Called(reminder)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockReminderRepository) FindByID(id uint) (domain.Reminder, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) FindByTask(taskID uint) ([]domain.Reminder, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) FindDue(before time.Time) ([]domain.Reminder, error) {
+	args := m.Called(before)
+	return args.Get(0).([]domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Update(reminder domain.Reminder) (domain.Reminder, error) {
+	args := m.Called(reminder)
+	return args.Get(0).(domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) DeleteByTask(taskID uint) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+// MockNotifier is a mock implementation of the Notifier interface
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func TestAddReminder_OffsetFromDueDate(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewReminderService(mockRepo, mockTaskRepo, nil, new(MockNotifier))
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	due := time.Date(2024, 5, 3, 17, 0, 0, 0, time.UTC)
+	offset := 60
+	fireAt := due.Add(-time.Hour)
+	expected := domain.Reminder{TaskID: 1, OffsetMinutes: &offset, FireAt: &fireAt, Status: domain.ReminderPending, CreatedAt: now}
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, DueDate: &due}, nil)
+	mockRepo.On("Save", expected).Return(uint(4), nil)
+
+	reminder, err := service.AddReminder(1, domain.Reminder{OffsetMinutes: &offset})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), reminder.ID)
+	assert.Equal(t, fireAt, *reminder.FireAt)
+}
+
+func TestAddReminder_NeedsExactlyOneTime(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewReminderService(new(MockReminderRepository), mockTaskRepo, nil, new(MockNotifier))
+
+	at := time.Now().Add(time.Hour)
+	offset := 10
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.AddReminder(1, domain.Reminder{RemindAt: &at, OffsetMinutes: &offset})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = service.AddReminder(1, domain.Reminder{})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestFireDue_DeliversToAssignee(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewReminderService(mockRepo, mockTaskRepo, mockUsers, mockNotifier)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	assigneeID := uint(3)
+	task := domain.Task{ID: 1, Title: "Pay rent", AssigneeID: &assigneeID}
+	user := domain.User{ID: 3, Name: "Ada", Email: "ada@example.com"}
+	due := domain.Reminder{ID: 4, TaskID: 1, FireAt: &now, Status: domain.ReminderPending}
+	sent := due
+	sent.Status = domain.ReminderSent
+	sent.SentAt = &now
+	mockRepo.On("FindDue", now).Return([]domain.Reminder{due}, nil)
+	mockTaskRepo.On("FindByID", uint(1)).Return(task, nil)
+	mockUsers.On("FindByID", uint(3)).Return(user, nil)
+	mockNotifier.On("Notify", domain.Notification{Reminder: due, Task: task, Recipient: &user}).Return(nil)
+	mockRepo.On("Update", sent).Return(sent, nil)
+
+	n, err := service.FireDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	mockRepo.AssertCalled(t, "Update", sent)
+}
+
+func TestFireDue_RetriesFailedDelivery(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewReminderService(mockRepo, mockTaskRepo, nil, mockNotifier)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	due := domain.Reminder{ID: 4, TaskID: 1, FireAt: &now, Status: domain.ReminderPending, Attempts: 1}
+	retryAt := now.Add(2 * time.Minute)
+	retried := due
+	retried.Attempts = 2
+	retried.LastError = "connection refused"
+	retried.FireAt = &retryAt
+	mockRepo.On("FindDue", now).Return([]domain.Reminder{due}, nil)
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockNotifier.On("Notify", mock.Anything).Return(errors.New("connection refused"))
+	mockRepo.On("Update", retried).Return(retried, nil)
+
+	n, err := service.FireDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	mockRepo.AssertCalled(t, "Update", retried)
+}
+
+func TestFireDue_SkipsCompletedTask(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockNotifier := new(MockNotifier)
+	service := NewReminderService(mockRepo, mockTaskRepo, nil, mockNotifier)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	due := domain.Reminder{ID: 4, TaskID: 1, FireAt: &now, Status: domain.ReminderPending}
+	skipped := due
+	skipped.Status = domain.ReminderSkipped
+	mockRepo.On("FindDue", now).Return([]domain.Reminder{due}, nil)
+	mockTaskRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Completed: true}, nil)
+	mockRepo.On("Update", skipped).Return(skipped, nil)
+
+	_, err := service.FireDue(context.Background())
+
+	assert.NoError(t, err)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestRescheduleTask_RearmsSentOffsetReminder(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	service := NewReminderService(mockRepo, new(MockTaskRepository), nil, new(MockNotifier))
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	offset := 30
+	at := now.Add(time.Hour)
+	absolute := domain.Reminder{ID: 1, TaskID: 7, RemindAt: &at, FireAt: &at, Status: domain.ReminderPending}
+	sentAt := now.Add(-time.Hour)
+	fired := domain.Reminder{ID: 2, TaskID: 7, OffsetMinutes: &offset, FireAt: &sentAt, Status: domain.ReminderSent, SentAt: &sentAt}
+
+	due := now.Add(24 * time.Hour)
+	fireAt := due.Add(-30 * time.Minute)
+	rearmed := fired
+	rearmed.Status = domain.ReminderPending
+	rearmed.SentAt = nil
+	rearmed.FireAt = &fireAt
+	mockRepo.On("FindByTask", uint(7)).Return([]domain.Reminder{absolute, fired}, nil)
+	mockRepo.On("Update", rearmed).Return(rearmed, nil)
+
+	err := service.RescheduleTask(domain.Task{ID: 7, DueDate: &due})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -0,0 +1,158 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxTagNameLength matches the size of the tags.name column
+const maxTagNameLength = 64
+
+type TagService struct {
+	repo     domain.TagRepository
+	taskRepo domain.TaskRepository
+	taskRecorder
+}
+
+// TagServiceOption configures optional collaborators of a TagService
+type TagServiceOption func(*TagService)
+
+// WithTagRevisions records a revision of every task tagged or untagged
+func WithTagRevisions(revisions domain.RevisionRepository) TagServiceOption {
+	return func(s *TagService) {
+		s.revisions = revisions
+	}
+}
+
+func NewTagService(repo domain.TagRepository, taskRepo domain.TaskRepository, opts ...TagServiceOption) *TagService {
+	s := &TagService{repo: repo, taskRepo: taskRepo, taskRecorder: taskRecorder{now: time.Now}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// As returns a copy of the service whose revisions are attributed to the
+// given user; a nil actor records changes without one.
+func (s *TagService) As(actor *uint) TagServiceInterface {
+	scoped := *s
+	scoped.actor = actor
+	return &scoped
+}
+
+// CreateTag creates a new tag; tag names are unique and case-insensitive.
+func (s *TagService) CreateTag(name string) (domain.Tag, error) {
+	name, err := NormalizeTagName(name)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	if _, err := s.repo.FindByName(name); err == nil {
+		return domain.Tag{}, fmt.Errorf("%w: tag %q already exists", ErrConflict, name)
+	} else if !errors.Is(err, domain.ErrTagNotFound) {
+		return domain.Tag{}, err
+	}
+
+	tag := domain.Tag{Name: name, CreatedAt: time.Now()}
+	id, err := s.repo.Save(tag)
+	tag.ID = id
+	return tag, err
+}
+
+// GetAllTags retrieves all tags ordered by name.
+func (s *TagService) GetAllTags() ([]domain.Tag, error) {
+	return s.repo.FindAll()
+}
+
+// GetTag retrieves a tag by its ID.
+func (s *TagService) GetTag(id uint) (domain.Tag, error) {
+	return s.repo.FindByID(id)
+}
+
+// RenameTag changes a tag's name; every task carrying the tag sees the new name.
+func (s *TagService) RenameTag(id uint, name string) (domain.Tag, error) {
+	tag, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	name, err = NormalizeTagName(name)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	if existing, err := s.repo.FindByName(name); err == nil && existing.ID != id {
+		return domain.Tag{}, fmt.Errorf("%w: tag %q already exists", ErrConflict, name)
+	} else if err != nil && !errors.Is(err, domain.ErrTagNotFound) {
+		return domain.Tag{}, err
+	}
+
+	tag.Name = name
+	return s.repo.Update(tag)
+}
+
+// DeleteTag removes a tag and detaches it from all tasks.
+func (s *TagService) DeleteTag(id uint) error {
+	return s.repo.Delete(id)
+}
+
+// TagTask attaches the named tag to a task, creating the tag if it does not exist yet.
+func (s *TagService) TagTask(taskID uint, name string) (domain.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	name, err = NormalizeTagName(name)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tag, err := s.repo.FindByName(name)
+	if errors.Is(err, domain.ErrTagNotFound) {
+		tag, err = s.CreateTag(name)
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if task.HasTag(tag.ID) {
+		return task, nil
+	}
+	previous := task
+	task.Tags = append(task.Tags[:len(task.Tags):len(task.Tags)], tag)
+	return s.saveChange(s.taskRepo, previous, task, domain.RevisionUpdate)
+}
+
+// UntagTask detaches a tag from a task.
+func (s *TagService) UntagTask(taskID, tagID uint) (domain.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !task.HasTag(tagID) {
+		return domain.Task{}, domain.ErrTagNotFound
+	}
+
+	tags := make([]domain.Tag, 0, len(task.Tags)-1)
+	for _, tag := range task.Tags {
+		if tag.ID != tagID {
+			tags = append(tags, tag)
+		}
+	}
+	previous := task
+	task.Tags = tags
+	return s.saveChange(s.taskRepo, previous, task, domain.RevisionUpdate)
+}
+
+// NormalizeTagName trims and lower-cases a tag name and checks it fits the schema.
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("%w: tag name must not be empty", ErrInvalidInput)
+	}
+	if len(name) > maxTagNameLength {
+		return "", fmt.Errorf("%w: tag name must be at most %d characters", ErrInvalidInput, maxTagNameLength)
+	}
+	return name, nil
+}
@@ -0,0 +1,16 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// TagServiceInterface defines the methods for managing tags and tagging tasks.
+type TagServiceInterface interface {
+	CreateTag(name string) (domain.Tag, error)
+	GetAllTags() ([]domain.Tag, error)
+	GetTag(id uint) (domain.Tag, error)
+	RenameTag(id uint, name string) (domain.Tag, error)
+	DeleteTag(id uint) error
+	TagTask(taskID uint, name string) (domain.Task, error)
+	UntagTask(taskID, tagID uint) (domain.Task, error)
+	// As scopes the service to a user, who is recorded as the actor of the task changes it makes
+	As(actor *uint) TagServiceInterface
+}
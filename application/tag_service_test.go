@@ -0,0 +1,107 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagRepository is a mock implementation of the TagRepository interface
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Save(tag domain.Tag) (uint, error) {
+	args := m.Called(tag)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByID(id uint) (domain.Tag, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByName(name string) (domain.Tag, error) {
+	args := m.Called(name)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindAll() ([]domain.Tag, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(tag domain.Tag) (domain.Tag, error) {
+	args := m.Called(tag)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestCreateTag(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByName", "backend").Return(domain.Tag{}, domain.ErrTagNotFound)
+	mockRepo.On("Save", mock.Anything).Return(uint(1), nil)
+
+	result, err := service.CreateTag("  Backend ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, "backend", result.Name)
+}
+
+func TestCreateTag_Duplicate(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByName", "oncall").Return(domain.Tag{ID: 3, Name: "oncall"}, nil)
+
+	_, err := service.CreateTag("oncall")
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTagTask_CreatesMissingTag(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTagService(mockRepo, mockTaskRepo)
+
+	task := domain.Task{ID: 7, Title: "Ship release"}
+	tagged := task
+	tagged.Tags = []domain.Tag{{ID: 2, Name: "release-42"}}
+
+	mockTaskRepo.On("FindByID", uint(7)).Return(task, nil)
+	mockRepo.On("FindByName", "release-42").Return(domain.Tag{}, domain.ErrTagNotFound)
+	mockRepo.On("Save", mock.Anything).Return(uint(2), nil)
+	mockTaskRepo.On("Update", mock.MatchedBy(func(t domain.Task) bool {
+		return t.ID == 7 && len(t.Tags) == 1 && t.Tags[0].ID == 2
+	})).Return(tagged, nil)
+
+	result, err := service.TagTask(7, "release-42")
+
+	assert.NoError(t, err)
+	assert.Equal(t, tagged, result)
+	mockRepo.AssertCalled(t, "Save", mock.Anything)
+}
+
+func TestUntagTask_NotTagged(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTagService(mockRepo, mockTaskRepo)
+
+	mockTaskRepo.On("FindByID", uint(7)).Return(domain.Task{ID: 7}, nil)
+
+	_, err := service.UntagTask(7, 2)
+
+	assert.ErrorIs(t, err, domain.ErrTagNotFound)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
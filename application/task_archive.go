@@ -0,0 +1,68 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// ArchiveTask moves a completed task to the archive, leaving it out of the
+// default task listings. Archiving an archived task is a no-op.
+func (s *TaskService) ArchiveTask(id uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !task.Completed {
+		return domain.Task{}, fmt.Errorf("%w: only completed tasks can be archived", ErrConflict)
+	}
+	if task.ArchivedAt != nil {
+		return task, nil
+	}
+	previous := task
+	now := s.now()
+	task.ArchivedAt = &now
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// UnarchiveTask brings an archived task back into the default task listings.
+func (s *TaskService) UnarchiveTask(id uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task.ArchivedAt == nil {
+		return task, nil
+	}
+	previous := task
+	task.ArchivedAt = nil
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// ArchiveCompletedBefore archives every task completed before the given time
+// and reports how many were archived.
+func (s *TaskService) ArchiveCompletedBefore(before time.Time) (int, error) {
+	if before.IsZero() {
+		return 0, fmt.Errorf("%w: completed_before is required", ErrInvalidInput)
+	}
+	archived, err := s.repo.ArchiveCompletedBefore(before, s.now())
+	if err != nil {
+		return 0, err
+	}
+	for _, task := range archived {
+		previous := task
+		previous.ArchivedAt = nil
+		if err := s.record(task.ID, domain.RevisionUpdate, previous, task); err != nil {
+			return len(archived), err
+		}
+	}
+	return len(archived), nil
+}
+
+// GetArchivedTasks retrieves the archived tasks whose title or description
+// contains query, most recently archived first.
+func (s *TaskService) GetArchivedTasks(query string) ([]domain.Task, error) {
+	return s.repo.FindArchived(strings.TrimSpace(query))
+}
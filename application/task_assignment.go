@@ -0,0 +1,68 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// AssignTask makes a user responsible for a task, replacing any previous assignee.
+func (s *TaskService) AssignTask(taskID, userID uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if s.users != nil {
+		if err := checkUser(s.users, &userID); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	previous := task
+	task.AssigneeID = &userID
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// UnassignTask leaves a task without an assignee.
+func (s *TaskService) UnassignTask(taskID uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task.AssigneeID == nil {
+		return task, nil
+	}
+
+	previous := task
+	task.AssigneeID = nil
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// GetTasksByAssignee retrieves the tasks assigned to a user.
+func (s *TaskService) GetTasksByAssignee(userID uint) ([]domain.Task, error) {
+	if s.users != nil {
+		if _, err := s.users.FindByID(userID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.FindByAssignee(userID)
+}
+
+// GetUnassignedTasks retrieves the tasks nobody is assigned to.
+func (s *TaskService) GetUnassignedTasks() ([]domain.Task, error) {
+	return s.repo.FindUnassigned()
+}
+
+// checkOwners verifies that the creator and assignee of a new task exist
+func (s *TaskService) checkOwners(task domain.Task) error {
+	if s.users == nil {
+		return nil
+	}
+	if err := checkUser(s.users, task.CreatorID); err != nil {
+		return fmt.Errorf("creator: %w", err)
+	}
+	if err := checkUser(s.users, task.AssigneeID); err != nil {
+		return fmt.Errorf("assignee: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxChecklistTextLength matches the size of the checklist_items.text column
+const maxChecklistTextLength = 255
+
+// AddChecklistItem appends an unchecked item to the end of a task's checklist.
+func (s *TaskService) AddChecklistItem(taskID uint, text string) (domain.Task, error) {
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	text, err = normalizeChecklistText(text)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	previous := snapshot(task)
+	task.Checklist = append(task.Checklist, domain.ChecklistItem{Text: text})
+	renumberChecklist(task.Checklist)
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// MoveChecklistItem moves an item to the given zero-based position; positions
+// past the end move it last.
+func (s *TaskService) MoveChecklistItem(taskID, itemID uint, position int) (domain.Task, error) {
+	if position < 0 {
+		return domain.Task{}, fmt.Errorf("%w: position must not be negative", ErrInvalidInput)
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	from, ok := task.ChecklistIndex(itemID)
+	if !ok {
+		return domain.Task{}, domain.ErrChecklistItemNotFound
+	}
+
+	previous := snapshot(task)
+	item := task.Checklist[from]
+	rest := append(task.Checklist[:from:from], task.Checklist[from+1:]...)
+	if position > len(rest) {
+		position = len(rest)
+	}
+	task.Checklist = append(rest[:position:position], append([]domain.ChecklistItem{item}, rest[position:]...)...)
+	renumberChecklist(task.Checklist)
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// ToggleChecklistItem flips an item between checked and unchecked. With
+// checklist auto-completion enabled, checking the last open item completes the
+// task when nothing else holds it open.
+func (s *TaskService) ToggleChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	i, ok := task.ChecklistIndex(itemID)
+	if !ok {
+		return domain.Task{}, domain.ErrChecklistItemNotFound
+	}
+
+	previous := snapshot(task)
+	task.Checklist[i].Checked = !task.Checklist[i].Checked
+	task, err = s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if progress, _ := task.ChecklistProgress(); !s.autoComplete || progress < 100 || task.Completed {
+		return task, nil
+	}
+	completed, err := s.MarkTaskCompleted(taskID, false)
+	if errors.Is(err, ErrConflict) {
+		// Open subtasks, blockers or the workflow keep the task open; the item stays checked
+		return task, nil
+	}
+	return completed, err
+}
+
+// RemoveChecklistItem deletes an item from a task's checklist.
+func (s *TaskService) RemoveChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	i, ok := task.ChecklistIndex(itemID)
+	if !ok {
+		return domain.Task{}, domain.ErrChecklistItemNotFound
+	}
+
+	previous := snapshot(task)
+	task.Checklist = append(task.Checklist[:i:i], task.Checklist[i+1:]...)
+	renumberChecklist(task.Checklist)
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// newChecklist copies the text and state of checklist items for a new task
+func newChecklist(items []domain.ChecklistItem, keepChecked bool) ([]domain.ChecklistItem, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	checklist := make([]domain.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		text, err := normalizeChecklistText(item.Text)
+		if err != nil {
+			return nil, err
+		}
+		checklist = append(checklist, domain.ChecklistItem{Text: text, Checked: keepChecked && item.Checked})
+	}
+	renumberChecklist(checklist)
+	return checklist, nil
+}
+
+// renumberChecklist makes item positions match their order
+func renumberChecklist(items []domain.ChecklistItem) {
+	for i := range items {
+		items[i].Position = i
+	}
+}
+
+// normalizeChecklistText trims an item's text and checks it fits the schema
+func normalizeChecklistText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("%w: checklist item text must not be empty", ErrInvalidInput)
+	}
+	if len(text) > maxChecklistTextLength {
+		return "", fmt.Errorf("%w: checklist item text must be at most %d characters", ErrInvalidInput, maxChecklistTextLength)
+	}
+	return text, nil
+}
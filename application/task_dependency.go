@@ -0,0 +1,93 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// GetBlockers retrieves the tasks a task is waiting on.
+func (s *TaskService) GetBlockers(id uint) ([]domain.Task, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBlockers(id)
+}
+
+// GetReadyTasks retrieves the open tasks whose blockers are all complete.
+func (s *TaskService) GetReadyTasks() ([]domain.Task, error) {
+	return s.repo.FindReady()
+}
+
+// AddBlocker records that a task cannot start until blockerID is done and
+// returns the task's blockers.
+func (s *TaskService) AddBlocker(id, blockerID uint) ([]domain.Task, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	if id == blockerID {
+		return nil, fmt.Errorf("%w: a task cannot block itself", ErrInvalidInput)
+	}
+	if _, err := s.repo.FindByID(blockerID); errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, fmt.Errorf("%w: blocking task %d does not exist", ErrInvalidInput, blockerID)
+	} else if err != nil {
+		return nil, err
+	}
+	if err := s.checkDependencyCycle(id, blockerID); err != nil {
+		return nil, err
+	}
+
+	dependency := domain.TaskDependency{TaskID: id, BlockerID: blockerID, CreatedAt: s.now()}
+	if err := s.repo.AddDependency(dependency); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBlockers(id)
+}
+
+// RemoveBlocker removes a blocker from a task.
+func (s *TaskService) RemoveBlocker(id, blockerID uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	return s.repo.RemoveDependency(id, blockerID)
+}
+
+// checkDependencyCycle walks the blockers of blockerID; reaching task id means
+// the new dependency would make the two tasks wait on each other
+func (s *TaskService) checkDependencyCycle(id, blockerID uint) error {
+	visited := map[uint]bool{blockerID: true}
+	queue := []uint{blockerID}
+	for len(queue) > 0 {
+		blockers, err := s.repo.FindBlockers(queue[0])
+		if err != nil {
+			return err
+		}
+		queue = queue[1:]
+		for _, blocker := range blockers {
+			if blocker.ID == id {
+				return fmt.Errorf("%w: task %d already depends on task %d", ErrConflict, blockerID, id)
+			}
+			if !visited[blocker.ID] {
+				visited[blocker.ID] = true
+				queue = append(queue, blocker.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// checkBlockers refuses to finish a task while any of its blockers is still
+// open. Tasks in finishing are about to be completed along with it.
+func (s *TaskService) checkBlockers(task domain.Task, finishing map[uint]bool) error {
+	blockers, err := s.repo.FindBlockers(task.ID)
+	if err != nil {
+		return err
+	}
+	for _, blocker := range blockers {
+		if !blocker.Completed && !finishing[blocker.ID] {
+			return fmt.Errorf("%w: task %d is blocked by open task %d", ErrConflict, task.ID, blocker.ID)
+		}
+	}
+	return nil
+}
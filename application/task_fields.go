@@ -0,0 +1,169 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// errCustomFieldsDisabled is returned by the custom field methods of a
+// TaskService built without WithCustomFields
+var errCustomFieldsDisabled = errors.New("custom fields are not configured")
+
+// WithCustomFields enables custom field values on tasks
+func WithCustomFields(fields domain.CustomFieldRepository) TaskServiceOption {
+	return func(s *TaskService) {
+		s.fields = fields
+	}
+}
+
+// SetFieldValue sets a task's value for one of its project's custom fields,
+// replacing any previous value. The value must match the field's type: a
+// string for text and enum fields, a number, or a YYYY-MM-DD date string.
+func (s *TaskService) SetFieldValue(taskID, fieldID uint, value any) (domain.Task, error) {
+	if s.fields == nil {
+		return domain.Task{}, errCustomFieldsDisabled
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	field, err := s.fields.FindByID(fieldID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task.ProjectID == nil || *task.ProjectID != field.ProjectID {
+		return domain.Task{}, fmt.Errorf("%w: field %d belongs to a project the task is not in", ErrInvalidInput, fieldID)
+	}
+	parsed, err := parseFieldValue(field, value)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	previous := task
+	parsed.TaskID = task.ID
+	values := make([]domain.FieldValue, 0, len(task.FieldValues)+1)
+	for _, existing := range task.FieldValues {
+		if existing.FieldID != fieldID {
+			values = append(values, existing)
+		}
+	}
+	task.FieldValues = append(values, parsed)
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// ClearFieldValue removes a task's value for a custom field. Clearing a value
+// the task does not have is a no-op.
+func (s *TaskService) ClearFieldValue(taskID, fieldID uint) (domain.Task, error) {
+	if s.fields == nil {
+		return domain.Task{}, errCustomFieldsDisabled
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if _, ok := task.FieldValue(fieldID); !ok {
+		return task, nil
+	}
+	previous := task
+	values := make([]domain.FieldValue, 0, len(task.FieldValues))
+	for _, existing := range task.FieldValues {
+		if existing.FieldID != fieldID {
+			values = append(values, existing)
+		}
+	}
+	task.FieldValues = values
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// GetTasksByFieldValues retrieves the tasks whose custom field values equal
+// every one of the given filters. Filters are keyed by field ID and written the
+// way the field's values are: as text, as a number or as a YYYY-MM-DD date.
+func (s *TaskService) GetTasksByFieldValues(filters map[uint]string) ([]domain.Task, error) {
+	if s.fields == nil {
+		return nil, errCustomFieldsDisabled
+	}
+	ids := make([]uint, 0, len(filters))
+	for id := range filters {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := make([]domain.FieldValue, 0, len(ids))
+	for _, id := range ids {
+		field, err := s.fields.FindByID(id)
+		if errors.Is(err, domain.ErrCustomFieldNotFound) {
+			return nil, fmt.Errorf("%w: unknown custom field %d", ErrInvalidInput, id)
+		} else if err != nil {
+			return nil, err
+		}
+		value, err := parseFieldFilter(field, filters[id])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return s.repo.FindByFieldValues(values)
+}
+
+// parseFieldValue checks a value decoded from JSON against the field's type and
+// converts it to the typed column it is stored in
+func parseFieldValue(field domain.CustomField, value any) (domain.FieldValue, error) {
+	parsed := domain.FieldValue{FieldID: field.ID}
+	switch field.Type {
+	case domain.FieldNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return parsed, fmt.Errorf("%w: field %q takes a number", ErrInvalidInput, field.Name)
+		}
+		parsed.NumberValue = &number
+		return parsed, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return parsed, fmt.Errorf("%w: field %q takes a string", ErrInvalidInput, field.Name)
+	}
+	text = strings.TrimSpace(text)
+	switch field.Type {
+	case domain.FieldDate:
+		date, err := time.Parse(domain.FieldDateLayout, text)
+		if err != nil {
+			return parsed, fmt.Errorf("%w: field %q takes a date formatted as YYYY-MM-DD", ErrInvalidInput, field.Name)
+		}
+		parsed.DateValue = &date
+	case domain.FieldEnum:
+		if !slices.Contains(field.Options, text) {
+			return parsed, fmt.Errorf("%w: field %q must be one of %s", ErrInvalidInput, field.Name, strings.Join(field.Options, ", "))
+		}
+		parsed.TextValue = &text
+	default:
+		if text == "" {
+			return parsed, fmt.Errorf("%w: field %q must not be empty", ErrInvalidInput, field.Name)
+		}
+		if utf8.RuneCountInString(text) > maxFieldTextLength {
+			return parsed, fmt.Errorf("%w: field %q must be at most %d characters", ErrInvalidInput, field.Name, maxFieldTextLength)
+		}
+		parsed.TextValue = &text
+	}
+	return parsed, nil
+}
+
+// parseFieldFilter converts a filter taken from the query string to the value it matches
+func parseFieldFilter(field domain.CustomField, raw string) (domain.FieldValue, error) {
+	if field.Type == domain.FieldNumber {
+		number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return domain.FieldValue{}, fmt.Errorf("%w: field %q takes a number", ErrInvalidInput, field.Name)
+		}
+		return parseFieldValue(field, number)
+	}
+	return parseFieldValue(field, raw)
+}
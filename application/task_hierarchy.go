@@ -0,0 +1,95 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// GetSubtasks retrieves the direct subtasks of a task.
+func (s *TaskService) GetSubtasks(id uint) ([]domain.Task, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindChildren(id)
+}
+
+// SetParent re-parents a task under another task, or makes it top-level when parentID is nil.
+func (s *TaskService) SetParent(id uint, parentID *uint) (domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := s.checkParent(id, parentID); err != nil {
+		return domain.Task{}, err
+	}
+	previous := task
+	task.ParentID = parentID
+	return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+}
+
+// checkParent verifies that parentID refers to an existing task and that
+// placing task id beneath it would not create a cycle. An id of 0 stands
+// for a task that has not been saved yet. The parent has to be outside the
+// trash, but the ancestors above it may be in it: the walk goes on through
+// them, as restoring them brings their part of the hierarchy back.
+func (s *TaskService) checkParent(id uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		return fmt.Errorf("%w: a task cannot be its own parent", ErrInvalidInput)
+	}
+
+	// Walk up from the new parent; reaching the task itself means a cycle
+	visited := map[uint]bool{}
+	next := parentID
+	for next != nil {
+		if id != 0 && *next == id {
+			return fmt.Errorf("%w: task %d is an ancestor of task %d", ErrConflict, id, *parentID)
+		}
+		if visited[*next] {
+			return fmt.Errorf("%w: task hierarchy above task %d contains a cycle", ErrConflict, *parentID)
+		}
+		visited[*next] = true
+
+		ancestor, err := s.repo.FindByID(*next)
+		if errors.Is(err, domain.ErrTaskNotFound) && next != parentID {
+			ancestor, err = s.repo.FindTrashedByID(*next)
+		}
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return fmt.Errorf("%w: parent task %d does not exist", ErrInvalidInput, *next)
+		}
+		if err != nil {
+			return err
+		}
+		next = ancestor.ParentID
+	}
+	return nil
+}
+
+// openDescendants collects every incomplete task below the given task.
+func (s *TaskService) openDescendants(id uint) ([]domain.Task, error) {
+	var open []domain.Task
+	visited := map[uint]bool{id: true}
+	queue := []uint{id}
+	for len(queue) > 0 {
+		children, err := s.repo.FindChildren(queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			queue = append(queue, child.ID)
+			if !child.Completed {
+				open = append(open, child)
+			}
+		}
+	}
+	return open, nil
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// errRevisionsDisabled is returned by the history methods of a TaskService
+// built without WithRevisions
+var errRevisionsDisabled = errors.New("revision history is not configured")
+
+// taskRecorder records the revisions of the task changes made by a service.
+// Without a revision repository nothing is recorded.
+type taskRecorder struct {
+	revisions domain.RevisionRepository
+	actor     *uint // User the revisions are attributed to; see As
+	now       func() time.Time
+}
+
+// WithRevisions records a revision whenever a task is created, changed in any
+// way, deleted, restored or reverted
+func WithRevisions(revisions domain.RevisionRepository) TaskServiceOption {
+	return func(s *TaskService) {
+		s.revisions = revisions
+	}
+}
+
+// As returns a copy of the service whose revisions are attributed to the
+// given user; a nil actor records changes without one.
+func (s *TaskService) As(actor *uint) TaskServiceInterface {
+	scoped := *s
+	scoped.actor = actor
+	return &scoped
+}
+
+// GetTaskHistory retrieves the revisions of a task, oldest first. The history
+// of a task in the trash can still be read.
+func (s *TaskService) GetTaskHistory(id uint) ([]domain.Revision, error) {
+	if s.revisions == nil {
+		return nil, errRevisionsDisabled
+	}
+	if _, err := s.repo.FindByID(id); errors.Is(err, domain.ErrTaskNotFound) {
+		if _, err := s.repo.FindTrashedByID(id); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return s.revisions.FindByTask(id)
+}
+
+// RevertTask puts a task's title, description, priority, due date, estimate,
+// assignee, parent, project, recurrence and archiving back the way they were
+// right after the given revision. The revert is itself recorded, so it can be
+// undone in turn. Status changes are not reverted; a task moves back through
+// the workflow with SetTaskStatus, and is only archived again while it is
+// complete. Tags, checklist, custom field values and manual order are shown in
+// the history but not reverted either.
+func (s *TaskService) RevertTask(id, revisionID uint) (domain.Task, error) {
+	if s.revisions == nil {
+		return domain.Task{}, errRevisionsDisabled
+	}
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	history, err := s.revisions.FindByTask(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	at := slices.IndexFunc(history, func(revision domain.Revision) bool {
+		return revision.ID == revisionID
+	})
+	if at < 0 {
+		return domain.Task{}, domain.ErrRevisionNotFound
+	}
+
+	// Undo every later revision, newest first
+	target := snapshot(task)
+	for i := len(history) - 1; i > at; i-- {
+		if err := target.Undo(history[i].Changes); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	reverted := snapshot(task)
+	if err := s.revertRelations(&reverted, target); err != nil {
+		return domain.Task{}, err
+	}
+	if err := applyEdits(&reverted, target); err != nil {
+		return domain.Task{}, err
+	}
+	return s.saveEdit(task, reverted, domain.RevisionRevert)
+}
+
+// revertRelations gives the task the assignee, parent, project, recurrence and
+// archiving of target, checking that they are still valid
+func (s *TaskService) revertRelations(task *domain.Task, target domain.Task) error {
+	if !sameID(task.AssigneeID, target.AssigneeID) {
+		if s.users != nil {
+			if err := checkUser(s.users, target.AssigneeID); err != nil {
+				return fmt.Errorf("assignee: %w", err)
+			}
+		}
+		task.AssigneeID = target.AssigneeID
+	}
+	if !sameID(task.ParentID, target.ParentID) {
+		if err := s.checkParent(task.ID, target.ParentID); err != nil {
+			return err
+		}
+		task.ParentID = target.ParentID
+	}
+	if !sameID(task.ProjectID, target.ProjectID) {
+		if s.projects != nil {
+			if err := checkProject(s.projects, target.ProjectID); err != nil {
+				return err
+			}
+		}
+		placeInProject(task, target.ProjectID)
+	}
+	task.Recurrence = target.Recurrence
+	// The status is not reverted, so only a complete task can be archived again
+	if target.ArchivedAt == nil || task.Completed {
+		task.ArchivedAt = target.ArchivedAt
+	}
+	return nil
+}
+
+// sameID reports whether two optional IDs are both unset or equal
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// snapshot copies the task's slices, so changing them leaves the copy as it was
+func snapshot(task domain.Task) domain.Task {
+	task.Tags = slices.Clone(task.Tags)
+	task.Checklist = slices.Clone(task.Checklist)
+	task.FieldValues = slices.Clone(task.FieldValues)
+	return task
+}
+
+// saveChange stores a changed task and records how it went from before as the
+// given action
+func (r taskRecorder) saveChange(repo domain.TaskRepository, before, task domain.Task, action domain.RevisionAction) (domain.Task, error) {
+	updated, err := repo.Update(task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := r.record(updated.ID, action, before, updated); err != nil {
+		return domain.Task{}, err
+	}
+	return updated, nil
+}
+
+// record stores a revision of the task describing how it went from before to
+// after. Edits that changed none of the tracked fields are not recorded.
+func (r taskRecorder) record(taskID uint, action domain.RevisionAction, before, after domain.Task) error {
+	if r.revisions == nil {
+		return nil
+	}
+	changes, err := domain.DiffTasks(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 && (action == domain.RevisionUpdate || action == domain.RevisionRevert) {
+		return nil
+	}
+	_, err = r.revisions.Save(domain.Revision{
+		TaskID:    taskID,
+		Action:    action,
+		ActorID:   r.actor,
+		Changes:   changes,
+		CreatedAt: r.now(),
+	})
+	return err
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// ReorderTask places a task directly before or after another task in the
+// manual order.
+func (s *TaskService) ReorderTask(id uint, move domain.TaskMove) (domain.Task, error) {
+	anchorID, after, ok := move.Anchor()
+	if !ok {
+		return domain.Task{}, fmt.Errorf("%w: exactly one of before and after is required", ErrInvalidInput)
+	}
+	if anchorID == id {
+		return domain.Task{}, fmt.Errorf("%w: a task cannot be moved next to itself", ErrInvalidInput)
+	}
+	if s.revisions == nil {
+		return s.repo.Move(id, anchorID, after)
+	}
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	moved, err := s.repo.Move(id, anchorID, after)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	// Only the moved task is recorded, not the tasks a respace shifts along
+	return moved, s.record(id, domain.RevisionUpdate, task, moved)
+}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// GetSeries retrieves every occurrence of the recurring series the task belongs to.
+func (s *TaskService) GetSeries(id uint) ([]domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindSeries(task.SeriesRoot())
+}
+
+// SetRecurrence changes the recurrence rule of the open occurrences of the
+// task's series. An empty rule stops the series: no further occurrences are
+// spawned when the open ones are completed.
+func (s *TaskService) SetRecurrence(id uint, rule string) (domain.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if rule != "" {
+		parsed, err := domain.ParseRecurrence(rule)
+		if err != nil {
+			return domain.Task{}, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		rule = parsed.String()
+	}
+
+	series, err := s.repo.FindSeries(task.SeriesRoot())
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	updated := task
+	open := 0
+	for _, occurrence := range series {
+		if occurrence.Completed {
+			continue
+		}
+		open++
+		previous := occurrence
+		occurrence.Recurrence = rule
+		saved, err := s.saveChange(s.repo, previous, occurrence, domain.RevisionUpdate)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if saved.ID == id {
+			updated = saved
+		}
+	}
+	if open == 0 {
+		return domain.Task{}, fmt.Errorf("%w: series has no open occurrences", ErrConflict)
+	}
+	return updated, nil
+}
+
+// spawnNextOccurrence creates the next task of a recurring series once task
+// has been completed. Nothing is spawned after the rule's COUNT or UNTIL, or
+// when the task was reopened and completed again and so already has a next
+// occurrence.
+func (s *TaskService) spawnNextOccurrence(task domain.Task) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+	rule, err := domain.ParseRecurrence(task.Recurrence)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	seriesID := task.SeriesRoot()
+	series, err := s.repo.FindSeries(seriesID)
+	if err != nil {
+		return err
+	}
+	if rule.Count > 0 && len(series) >= rule.Count {
+		return nil
+	}
+	for _, occurrence := range series {
+		// Occurrences are spawned one after another, so later ones have higher IDs
+		if occurrence.ID > task.ID {
+			return nil
+		}
+	}
+
+	// Tasks without a due date repeat relative to when they were created
+	anchor := task.CreatedAt
+	if task.DueDate != nil {
+		anchor = *task.DueDate
+	}
+	due, ok := rule.Next(anchor.In(task.DueLocation()))
+	if !ok {
+		return nil
+	}
+
+	next := domain.Task{
+		Title:       task.Title,
+		Description: task.Description,
+		CreatedAt:   s.now(),
+		DueDate:     &due,
+		DueHasTime:  task.DueHasTime,
+		DueTimezone: task.DueTimezone,
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		ParentID:    task.ParentID,
+		Recurrence:  task.Recurrence,
+		SeriesID:    &seriesID,
+		ProjectID:   task.ProjectID,
+		AssigneeID:  task.AssigneeID,
+		Estimate:    task.Estimate,
+		Status:      domain.StatusTodo,
+	}
+	// Every occurrence starts with the checklist unchecked
+	if next.Checklist, err = newChecklist(task.Checklist, false); err != nil {
+		return err
+	}
+	for _, value := range task.FieldValues {
+		value.TaskID = 0
+		next.FieldValues = append(next.FieldValues, value)
+	}
+	if err := normalizeDueDate(&next); err != nil {
+		return err
+	}
+	id, err := s.repo.Save(next)
+	if err != nil {
+		return err
+	}
+	next.ID = id
+	return s.record(id, domain.RevisionCreate, domain.Task{}, next)
+}
+
+// normalizeRecurrence validates the task's recurrence rule and stores it in canonical form.
+func normalizeRecurrence(task *domain.Task) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+	rule, err := domain.ParseRecurrence(task.Recurrence)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	task.Recurrence = rule.String()
+	return nil
+}
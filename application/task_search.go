@@ -0,0 +1,132 @@
+package application
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// snippetLength is roughly how many characters of a description a search snippet shows
+const snippetLength = 160
+
+// SearchTasks finds the tasks whose title or description has a word starting
+// with each word of the query, most relevant first, and marks where they matched.
+// Archived tasks are searched too; tasks in the trash are not.
+func (s *TaskService) SearchTasks(query string, limit int) ([]domain.SearchHit, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: search query needs at least one word", ErrInvalidInput)
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxPageSize)
+	}
+
+	hits, err := s.repo.Search(terms, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hits {
+		hits[i].Highlights = domain.SearchHighlights{
+			Title:       highlight(hits[i].Task.Title, terms),
+			Description: highlight(snippet(hits[i].Task.Description, terms), terms),
+		}
+	}
+	return hits, nil
+}
+
+// span is the byte range of a word within a text
+type span struct {
+	start, end int
+}
+
+// wordSpans returns where each word of text starts and ends, splitting words
+// the same way domain.Tokenize does
+func wordSpans(text string) []span {
+	var spans []span
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			spans = append(spans, span{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(text)})
+	}
+	return spans
+}
+
+// highlight HTML-escapes text and wraps every word matching one of the terms in <mark> tags
+func highlight(text string, terms []string) string {
+	var b strings.Builder
+	last := 0
+	for _, word := range wordSpans(text) {
+		if !domain.MatchesTerm(text[word.start:word.end], terms) {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:word.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[word.start:word.end]))
+		b.WriteString("</mark>")
+		last = word.end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// snippet cuts a long text down to about snippetLength characters around its
+// first word matching one of the terms, cutting only between words and
+// marking the cuts with an ellipsis
+func snippet(text string, terms []string) string {
+	if utf8.RuneCountInString(text) <= snippetLength {
+		return text
+	}
+	words := wordSpans(text)
+	if len(words) == 0 {
+		return string([]rune(text)[:snippetLength]) + "…"
+	}
+	first := 0
+	for i, word := range words {
+		if domain.MatchesTerm(text[word.start:word.end], terms) {
+			first = i
+			break
+		}
+	}
+
+	// Keep a little context before the match and fill the rest after it
+	from := first
+	for from > 0 && utf8.RuneCountInString(text[words[from-1].start:words[first].end]) <= snippetLength/4 {
+		from--
+	}
+	to := first
+	for to+1 < len(words) && utf8.RuneCountInString(text[words[from].start:words[to+1].end]) <= snippetLength {
+		to++
+	}
+
+	start, end := words[from].start, words[to].end
+	if from == 0 {
+		start = 0
+	}
+	if to == len(words)-1 {
+		end = len(text)
+	}
+	cut := text[start:end]
+	if start > 0 {
+		cut = "…" + cut
+	}
+	if end < len(text) {
+		cut += "…"
+	}
+	return cut
+}
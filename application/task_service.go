@@ -1,29 +1,144 @@
 package application
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/krishnakumarkp/to-do/domain"
 )
 
+const (
+	// DefaultPageSize is the number of tasks in a page when a query sets no limit
+	DefaultPageSize = 50
+
+	// MaxPageSize is the largest number of tasks a single page may hold
+	MaxPageSize = 200
+)
+
 type TaskService struct {
-	repo domain.TaskRepository
+	repo     domain.TaskRepository
+	projects domain.ProjectRepository
+	users    domain.UserRepository
+	workflow domain.Workflow
+	onDelete []func(taskID uint) error
+	onDue    []func(task domain.Task) error
+	taskRecorder
+
+	autoComplete bool
+	timeEntries  domain.TimeEntryRepository
+	fields       domain.CustomFieldRepository
+}
+
+// TaskServiceOption configures optional collaborators of a TaskService
+type TaskServiceOption func(*TaskService)
+
+// WithProjects lets the service check that the projects tasks are created in exist
+func WithProjects(projects domain.ProjectRepository) TaskServiceOption {
+	return func(s *TaskService) {
+		s.projects = projects
+	}
 }
 
-func NewTaskService(repo domain.TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+// WithUsers lets the service check that the creators and assignees of tasks exist
+func WithUsers(users domain.UserRepository) TaskServiceOption {
+	return func(s *TaskService) {
+		s.users = users
+	}
 }
 
-func (s *TaskService) CreateTask(title, description string) (domain.Task, error) {
+// WithWorkflow replaces the default status transition table
+func WithWorkflow(workflow domain.Workflow) TaskServiceOption {
+	return func(s *TaskService) {
+		s.workflow = workflow
+	}
+}
+
+// WithDeleteHook registers a function that removes data owned by a task
+// (such as its comments) right before the task itself is deleted
+func WithDeleteHook(hook func(taskID uint) error) TaskServiceOption {
+	return func(s *TaskService) {
+		s.onDelete = append(s.onDelete, hook)
+	}
+}
+
+// WithDueDateHook registers a function that is told about a task whose due
+// date was changed (such as one rescheduling its reminders)
+func WithDueDateHook(hook func(task domain.Task) error) TaskServiceOption {
+	return func(s *TaskService) {
+		s.onDue = append(s.onDue, hook)
+	}
+}
+
+// WithChecklistAutoComplete completes a task as soon as every item of its checklist is checked
+func WithChecklistAutoComplete() TaskServiceOption {
+	return func(s *TaskService) {
+		s.autoComplete = true
+	}
+}
+
+func NewTaskService(repo domain.TaskRepository, opts ...TaskServiceOption) *TaskService {
+	s := &TaskService{repo: repo, workflow: domain.DefaultWorkflow, taskRecorder: taskRecorder{now: time.Now}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *TaskService) CreateTask(input domain.Task) (domain.Task, error) {
 	task := domain.Task{
-		Title:       title,
-		Description: description,
+		Title:       input.Title,
+		Description: input.Description,
 		Completed:   false,
-		CreatedAt:   time.Now(),
+		Status:      input.Status,
+		CreatedAt:   s.now(),
+		DueDate:     input.DueDate,
+		DueHasTime:  input.DueHasTime,
+		DueTimezone: input.DueTimezone,
+		Priority:    input.Priority,
+		ParentID:    input.ParentID,
+		Recurrence:  input.Recurrence,
+		ProjectID:   input.ProjectID,
+		AssigneeID:  input.AssigneeID,
+		CreatorID:   input.CreatorID,
+		Estimate:    input.Estimate,
+	}
+	if err := validatePriority(task.Priority); err != nil {
+		return domain.Task{}, err
+	}
+	if err := validateEstimate(task.Estimate); err != nil {
+		return domain.Task{}, err
+	}
+	if err := validateInitialStatus(&task); err != nil {
+		return domain.Task{}, err
+	}
+	checklist, err := newChecklist(input.Checklist, true)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Checklist = checklist
+	if err := s.checkParent(0, task.ParentID); err != nil {
+		return domain.Task{}, err
+	}
+	if s.projects != nil {
+		if err := checkProject(s.projects, task.ProjectID); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	if err := s.checkOwners(task); err != nil {
+		return domain.Task{}, err
+	}
+	if err := normalizeRecurrence(&task); err != nil {
+		return domain.Task{}, err
+	}
+	if err := normalizeDueDate(&task); err != nil {
+		return domain.Task{}, err
 	}
 	id, err := s.repo.Save(task)
 	task.ID = id
-	return task, err
+	if err != nil {
+		return task, err
+	}
+	return task, s.record(id, domain.RevisionCreate, domain.Task{}, task)
 }
 
 // GetTask retrieves a task by its ID.
@@ -40,17 +155,136 @@ func (s *TaskService) GetAllTasks() ([]domain.Task, error) {
 	return s.repo.FindAll()
 }
 
-func (s *TaskService) MarkTaskCompleted(id uint) (domain.Task, error) {
+// QueryTasks retrieves one page of the tasks matching the query. The query is
+// sorted by creation time unless it names another field, and a page holds
+// DefaultPageSize tasks unless it asks for up to MaxPageSize.
+func (s *TaskService) QueryTasks(query domain.TaskQuery) (domain.TaskPage, error) {
+	if query.Sort == "" {
+		query.Sort = domain.SortByCreated
+	}
+	if !query.Sort.Valid() {
+		return domain.TaskPage{}, fmt.Errorf("%w: unknown sort field %q", ErrInvalidInput, query.Sort)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit < 0 || query.Limit > MaxPageSize {
+		return domain.TaskPage{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxPageSize)
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedAfter.Before(*query.CreatedBefore) {
+		return domain.TaskPage{}, fmt.Errorf("%w: created_after must be before created_before", ErrInvalidInput)
+	}
+	return s.repo.Query(query)
+}
+
+// GetTasksByTags retrieves tasks carrying any of the named tags, or all of them when matchAll is set.
+func (s *TaskService) GetTasksByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := NormalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", ErrInvalidInput)
+	}
+	return s.repo.FindByTags(normalized, matchAll)
+}
+
+// GetOverdueTasks retrieves open tasks whose deadline has already passed.
+func (s *TaskService) GetOverdueTasks() ([]domain.Task, error) {
+	now := s.now()
+	candidates, err := s.repo.FindOpenDueBefore(now)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, 0, len(candidates))
+	for _, task := range candidates {
+		if task.IsOverdue(now) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// GetTasksDueToday retrieves open tasks due on the current day in their own timezone.
+func (s *TaskService) GetTasksDueToday() ([]domain.Task, error) {
+	now := s.now()
+	// Timezones are at most a day apart, so two days ahead covers every candidate
+	candidates, err := s.repo.FindOpenDueBefore(now.Add(48 * time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, 0, len(candidates))
+	for _, task := range candidates {
+		if task.IsDueOn(now) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// GetTasksDueWithin retrieves open tasks that are not yet overdue and fall due in the next given number of days.
+func (s *TaskService) GetTasksDueWithin(days int) ([]domain.Task, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("%w: days must not be negative", ErrInvalidInput)
+	}
+	now := s.now()
+	candidates, err := s.repo.FindOpenDueBefore(now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, 0, len(candidates))
+	for _, task := range candidates {
+		if !task.IsOverdue(now) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// MarkTaskCompleted moves a task to done. A task with open subtasks is only
+// completed when force is set, in which case its open subtasks are completed too.
+// Tasks still blocked by open tasks are never completed.
+func (s *TaskService) MarkTaskCompleted(id uint, force bool) (domain.Task, error) {
 	task, err := s.repo.FindByID(id)
 	if err != nil {
 		return task, err
 	}
-	task.Completed = true
-	task, err = s.repo.Update(task)
+
+	open, err := s.openDescendants(id)
 	if err != nil {
-		return task, err
+		return domain.Task{}, err
 	}
-	return task, nil
+	if len(open) > 0 && !force {
+		return domain.Task{}, fmt.Errorf("%w: task has %d open subtasks", ErrConflict, len(open))
+	}
+	// Check every transition up front so a refused subtask leaves nothing half done
+	finishing := map[uint]bool{task.ID: true}
+	for _, child := range open {
+		finishing[child.ID] = true
+	}
+	for _, t := range append(open, task) {
+		if err := s.checkTransition(t, domain.StatusDone); err != nil {
+			return domain.Task{}, err
+		}
+		if err := s.checkBlockers(t, finishing); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	for _, child := range open {
+		if _, err := s.transitionTask(child, domain.StatusDone); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	return s.transitionTask(task, domain.StatusDone)
 }
 
 func (s *TaskService) UpdateTask(id uint, task domain.Task) (domain.Task, error) {
@@ -61,19 +295,108 @@ func (s *TaskService) UpdateTask(id uint, task domain.Task) (domain.Task, error)
 	}
 
 	// Update the task fields with the new data
+	edited := existingTask
+	if err := applyEdits(&edited, task); err != nil {
+		return domain.Task{}, err
+	}
+	return s.saveEdit(existingTask, edited, domain.RevisionUpdate)
+}
+
+// applyEdits copies the editable fields of task onto existingTask and validates them
+func applyEdits(existingTask *domain.Task, task domain.Task) error {
 	existingTask.Title = task.Title
 	existingTask.Description = task.Description
-	// Optionally, you can update other fields like Completed if necessary
+	existingTask.DueDate = task.DueDate
+	existingTask.DueHasTime = task.DueHasTime
+	existingTask.DueTimezone = task.DueTimezone
+	existingTask.Priority = task.Priority
+	existingTask.Estimate = task.Estimate
+	if err := validatePriority(existingTask.Priority); err != nil {
+		return err
+	}
+	if err := validateEstimate(existingTask.Estimate); err != nil {
+		return err
+	}
+	return normalizeDueDate(existingTask)
+}
 
-	// Save the updated task
-	updatedTask, err := s.repo.Update(existingTask)
+// saveEdit saves an edited task, records the change as the given action and
+// tells the due date hooks when the due date moved
+func (s *TaskService) saveEdit(previous, task domain.Task, action domain.RevisionAction) (domain.Task, error) {
+	updatedTask, err := s.saveChange(s.repo, previous, task, action)
 	if err != nil {
 		return domain.Task{}, err
 	}
+	if !sameTime(previous.DueDate, updatedTask.DueDate) {
+		for _, hook := range s.onDue {
+			if err := hook(updatedTask); err != nil {
+				return domain.Task{}, err
+			}
+		}
+	}
 
 	return updatedTask, nil
 }
 
+// DeleteTask moves a task to the trash. Nothing is removed until the task is
+// purged, so it can be restored with all of its data.
 func (s *TaskService) DeleteTask(id uint) error {
-	return s.repo.Delete(id)
+	if s.revisions == nil {
+		return s.repo.Trash(id, s.now())
+	}
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	at := s.now()
+	if err := s.repo.Trash(id, at); err != nil {
+		return err
+	}
+	trashed := task
+	trashed.DeletedAt = &at
+	return s.record(id, domain.RevisionDelete, task, trashed)
+}
+
+// sameTime reports whether two optional times are both unset or the same instant
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// validatePriority rejects priority values outside the known levels
+func validatePriority(p domain.Priority) error {
+	if !p.Valid() {
+		return fmt.Errorf("%w: unknown priority %d", ErrInvalidInput, int(p))
+	}
+	return nil
+}
+
+// normalizeDueDate validates the due timezone and pins date-only due dates
+// to midnight of the given calendar day in that timezone.
+func normalizeDueDate(task *domain.Task) error {
+	if task.DueDate == nil {
+		task.DueHasTime = false
+		task.DueTimezone = ""
+		return nil
+	}
+
+	loc := time.Local
+	if task.DueTimezone != "" {
+		l, err := time.LoadLocation(task.DueTimezone)
+		if err != nil {
+			return fmt.Errorf("%w: unknown due timezone %q", ErrInvalidInput, task.DueTimezone)
+		}
+		loc = l
+	}
+
+	due := task.DueDate.In(loc)
+	if !task.DueHasTime {
+		// Keep the calendar day as the client wrote it, regardless of offset
+		y, m, d := task.DueDate.Date()
+		due = time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+	task.DueDate = &due
+	return nil
 }
@@ -1,14 +1,63 @@
 package application
 
-import "github.com/krishnakumarkp/to-do/domain"
+import (
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
 
 // TaskService defines the methods for managing tasks.
 
 type TaskServiceInterface interface {
-	CreateTask(title, description string) (domain.Task, error)
+	CreateTask(task domain.Task) (domain.Task, error)
 	GetAllTasks() ([]domain.Task, error)
+	QueryTasks(query domain.TaskQuery) (domain.TaskPage, error)
+	SearchTasks(query string, limit int) ([]domain.SearchHit, error)
+	GetTasksByTags(names []string, matchAll bool) ([]domain.Task, error)
+	GetOverdueTasks() ([]domain.Task, error)
+	GetTasksDueToday() ([]domain.Task, error)
+	GetTasksDueWithin(days int) ([]domain.Task, error)
 	GetTask(id uint) (domain.Task, error)
 	UpdateTask(id uint, task domain.Task) (domain.Task, error)
-	MarkTaskCompleted(id uint) (domain.Task, error)
+	MarkTaskCompleted(id uint, force bool) (domain.Task, error)
+	SetTaskStatus(id uint, status domain.Status) (domain.Task, error)
+	GetSubtasks(id uint) ([]domain.Task, error)
+	SetParent(id uint, parentID *uint) (domain.Task, error)
+	GetSeries(id uint) ([]domain.Task, error)
+	SetRecurrence(id uint, rule string) (domain.Task, error)
+	GetBlockers(id uint) ([]domain.Task, error)
+	GetReadyTasks() ([]domain.Task, error)
+	AddBlocker(id, blockerID uint) ([]domain.Task, error)
+	RemoveBlocker(id, blockerID uint) error
+	AddChecklistItem(taskID uint, text string) (domain.Task, error)
+	MoveChecklistItem(taskID, itemID uint, position int) (domain.Task, error)
+	ToggleChecklistItem(taskID, itemID uint) (domain.Task, error)
+	RemoveChecklistItem(taskID, itemID uint) (domain.Task, error)
+	AssignTask(taskID, userID uint) (domain.Task, error)
+	UnassignTask(taskID uint) (domain.Task, error)
+	GetTasksByAssignee(userID uint) ([]domain.Task, error)
+	GetUnassignedTasks() ([]domain.Task, error)
+	StartTimer(taskID, userID uint) (domain.TimeEntry, error)
+	StopTimer(taskID, userID uint) (domain.TimeEntry, error)
+	AddTimeEntry(taskID uint, entry domain.TimeEntry) (domain.TimeEntry, error)
+	GetTimeEntries(taskID uint) ([]domain.TimeEntry, error)
+	DeleteTimeEntry(taskID, entryID uint) error
+	GetTaskTimeTotals(taskID uint) (domain.TimeTotals, error)
+	GetProjectTimeTotals(projectID uint) (domain.TimeTotals, error)
 	DeleteTask(id uint) error
+	SetFieldValue(taskID, fieldID uint, value any) (domain.Task, error)
+	ClearFieldValue(taskID, fieldID uint) (domain.Task, error)
+	GetTasksByFieldValues(filters map[uint]string) ([]domain.Task, error)
+	ReorderTask(id uint, move domain.TaskMove) (domain.Task, error)
+	ArchiveTask(id uint) (domain.Task, error)
+	UnarchiveTask(id uint) (domain.Task, error)
+	ArchiveCompletedBefore(before time.Time) (int, error)
+	GetArchivedTasks(query string) ([]domain.Task, error)
+	GetTrash() ([]domain.Task, error)
+	RestoreTask(id uint) (domain.Task, error)
+	PurgeTask(id uint) error
+	GetTaskHistory(id uint) ([]domain.Revision, error)
+	RevertTask(id, revisionID uint) (domain.Task, error)
+	// As scopes the service to a user, who is recorded as the actor of the changes it makes
+	As(actor *uint) TaskServiceInterface
 }
@@ -1,9 +1,13 @@
 package application
 
 import (
+	"encoding/json"
 	"errors"
+	"html"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/krishnakumarkp/to-do/domain"
 
@@ -31,16 +35,196 @@ func (m *MockTaskRepository) FindAll() ([]domain.Task, error) {
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) FindByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	args := m.Called(names, matchAll)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindOpenDueBefore(before time.Time) ([]domain.Task, error) {
+	args := m.Called(before)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindChildren(parentID uint) ([]domain.Task, error) {
+	args := m.Called(parentID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindSeries(seriesID uint) ([]domain.Task, error) {
+	args := m.Called(seriesID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindByProject(projectID uint) ([]domain.Task, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CountByProject() (map[uint]domain.TaskCounts, error) {
+	args := m.Called()
+	return args.Get(0).(map[uint]domain.TaskCounts), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindBlockers(taskID uint) ([]domain.Task, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindByAssignee(userID uint) ([]domain.Task, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindUnassigned() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindReady() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddDependency(dependency domain.TaskDependency) error {
+	args := m.Called(dependency)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveDependency(taskID, blockerID uint) error {
+	args := m.Called(taskID, blockerID)
+	return args.Error(0)
+}
+
 func (m *MockTaskRepository) Update(task domain.Task) (domain.Task, error) {
 	args := m.Called(task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) FindByFieldValues(values []domain.FieldValue) ([]domain.Task, error) {
+	args := m.Called(values)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Query(query domain.TaskQuery) (domain.TaskPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(domain.TaskPage), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(terms []string, limit int) ([]domain.SearchHit, error) {
+	args := m.Called(terms, limit)
+	return args.Get(0).([]domain.SearchHit), args.Error(1)
+}
+
+func (m *MockTaskRepository) Move(id, anchorID uint, after bool) (domain.Task, error) {
+	args := m.Called(id, anchorID, after)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindArchived(query string) ([]domain.Task, error) {
+	args := m.Called(query)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ArchiveCompletedBefore(before, at time.Time) ([]domain.Task, error) {
+	args := m.Called(before, at)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Trash(id uint, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) FindTrashed() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindTrashedByID(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) Delete(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+// MockTimeEntryRepository is a mock implementation of the TimeEntryRepository interface
+type MockTimeEntryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeEntryRepository) Save(entry domain.TimeEntry) (uint, error) {
+	args := m.Called(entry)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Start(entry domain.TimeEntry) (uint, error) {
+	args := m.Called(entry)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindByID(id uint) (domain.TimeEntry, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindByTask(taskID uint) ([]domain.TimeEntry, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindByProject(projectID uint) ([]domain.TimeEntry, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindRunning(userID uint) (domain.TimeEntry, error) {
+	args := m.Called(userID)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Update(entry domain.TimeEntry) (domain.TimeEntry, error) {
+	args := m.Called(entry)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) DeleteByTask(taskID uint) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+// MockRevisionRepository is a mock implementation of the RevisionRepository interface
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) Save(revision domain.Revision) (uint, error) {
+	args := m.Called(revision)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRevisionRepository) FindByTask(taskID uint) ([]domain.Revision, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) DeleteByTask(taskID uint) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
 func TestCreateTask(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo)
@@ -53,7 +237,7 @@ func TestCreateTask(t *testing.T) {
 	}
 	mockRepo.On("Save", mock.Anything).Return(uint(1), nil)
 
-	result, err := service.CreateTask(task.Title, task.Description)
+	result, err := service.CreateTask(task)
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), result.ID)
@@ -61,6 +245,42 @@ func TestCreateTask(t *testing.T) {
 	mockRepo.AssertCalled(t, "Save", mock.Anything)
 }
 
+func TestCreateTask_DateOnlyDueDate(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	// A date-only due date keeps the calendar day the client sent, pinned to midnight in the task's timezone
+	due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Save", mock.Anything).Return(uint(1), nil)
+
+	result, err := service.CreateTask(domain.Task{Title: "Release", DueDate: &due, DueTimezone: "America/New_York"})
+
+	assert.NoError(t, err)
+	loc, _ := time.LoadLocation("America/New_York")
+	assert.True(t, result.DueDate.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, loc)))
+}
+
+func TestCreateTask_InvalidDueTimezone(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	due := time.Now()
+	_, err := service.CreateTask(domain.Task{Title: "Release", DueDate: &due, DueTimezone: "Mars/Olympus"})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCreateTask_InvalidPriority(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	_, err := service.CreateTask(domain.Task{Title: "Triage", Priority: domain.Priority(42)})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
 func TestGetTask_Success(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo)
@@ -104,18 +324,91 @@ func TestGetAllTasks(t *testing.T) {
 	mockRepo.AssertCalled(t, "FindAll")
 }
 
+func TestGetTasksByTags(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	tasks := []domain.Task{{ID: 1, Title: "Page triage", Tags: []domain.Tag{{ID: 1, Name: "oncall"}}}}
+	mockRepo.On("FindByTags", []string{"oncall", "backend"}, true).Return(tasks, nil)
+
+	// Names are normalized and de-duplicated before reaching the repository
+	result, err := service.GetTasksByTags([]string{"OnCall", "backend", "oncall"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tasks, result)
+	mockRepo.AssertCalled(t, "FindByTags", []string{"oncall", "backend"}, true)
+}
+
+func TestGetOverdueTasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	pastDeadline := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	dueToday := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{
+		{ID: 1, Title: "Timed, missed", DueDate: &pastDeadline, DueHasTime: true, DueTimezone: "UTC"},
+		{ID: 2, Title: "Date-only, still today", DueDate: &dueToday, DueTimezone: "UTC"},
+	}
+	mockRepo.On("FindOpenDueBefore", now).Return(tasks, nil)
+
+	result, err := service.GetOverdueTasks()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{tasks[0]}, result)
+}
+
+func TestGetTasksDueToday(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	today := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{
+		{ID: 1, Title: "Today", DueDate: &today, DueTimezone: "UTC"},
+		{ID: 2, Title: "Tomorrow", DueDate: &tomorrow, DueTimezone: "UTC"},
+	}
+	mockRepo.On("FindOpenDueBefore", now.Add(48*time.Hour)).Return(tasks, nil)
+
+	result, err := service.GetTasksDueToday()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{tasks[0]}, result)
+}
+
+func TestGetTasksDueWithin_NegativeDays(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	_, err := service.GetTasksDueWithin(-1)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "FindOpenDueBefore", mock.Anything)
+}
+
 func TestMarkTaskCompleted(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
 
 	task := domain.Task{ID: 1, Title: "Test Task", Completed: false}
 	updatedTask := task
 	updatedTask.Completed = true
+	updatedTask.Status = domain.StatusDone
+	updatedTask.CompletedAt = &now
 
 	mockRepo.On("FindByID", uint(1)).Return(task, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", mock.Anything).Return([]domain.Task{}, nil)
 	mockRepo.On("Update", updatedTask).Return(updatedTask, nil)
 
-	result, err := service.MarkTaskCompleted(1)
+	result, err := service.MarkTaskCompleted(1, false)
 
 	assert.NoError(t, err)
 	assert.True(t, result.Completed)
@@ -123,14 +416,1219 @@ func TestMarkTaskCompleted(t *testing.T) {
 	mockRepo.AssertCalled(t, "Update", updatedTask)
 }
 
-func TestDeleteTask(t *testing.T) {
+func TestSetTaskStatus_InProgress(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo)
 
-	mockRepo.On("Delete", uint(1)).Return(nil)
+	task := domain.Task{ID: 1, Title: "Write docs", Status: domain.StatusTodo}
+	started := task
+	started.Status = domain.StatusInProgress
 
-	err := service.DeleteTask(1)
+	mockRepo.On("FindByID", uint(1)).Return(task, nil)
+	mockRepo.On("Update", started).Return(started, nil)
+
+	result, err := service.SetTaskStatus(1, domain.StatusInProgress)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusInProgress, result.Status)
+	assert.False(t, result.Completed)
+}
+
+func TestSetTaskStatus_TransitionNotAllowed(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Status: domain.StatusBlocked}, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+
+	_, err := service.MarkTaskCompleted(1, false)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSetTaskStatus_ReopenClearsCompletedAt(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	task := domain.Task{ID: 1, Completed: true, Status: domain.StatusDone, CompletedAt: &completedAt}
+	reopened := domain.Task{ID: 1, Status: domain.StatusTodo}
+
+	mockRepo.On("FindByID", uint(1)).Return(task, nil)
+	mockRepo.On("Update", reopened).Return(reopened, nil)
+
+	result, err := service.SetTaskStatus(1, domain.StatusTodo)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.CompletedAt)
+	mockRepo.AssertCalled(t, "Update", reopened)
+}
+
+func TestSetTaskStatus_CustomWorkflow(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	// A workflow without review: in_progress can only go back to todo
+	service := NewTaskService(mockRepo, WithWorkflow(domain.Workflow{
+		domain.StatusTodo:       {domain.StatusInProgress},
+		domain.StatusInProgress: {domain.StatusTodo},
+	}))
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Status: domain.StatusInProgress}, nil)
+
+	_, err := service.SetTaskStatus(1, domain.StatusInReview)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestMarkTaskCompleted_OpenSubtasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	parentID := uint(1)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Release"}, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{{ID: 2, Title: "Changelog", ParentID: &parentID}}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+
+	_, err := service.MarkTaskCompleted(1, false)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestMarkTaskCompleted_ForceCompletesSubtasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	parentID := uint(1)
+	child := domain.Task{ID: 2, Title: "Changelog", ParentID: &parentID}
+	completedChild := child
+	completedChild.SetStatus(domain.StatusDone, now)
+	completedParent := domain.Task{ID: 1, Title: "Release"}
+	completedParent.SetStatus(domain.StatusDone, now)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Release"}, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{child}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", mock.Anything).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", completedChild).Return(completedChild, nil)
+	mockRepo.On("Update", completedParent).Return(completedParent, nil)
+
+	result, err := service.MarkTaskCompleted(1, true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Completed)
+	mockRepo.AssertCalled(t, "Update", completedChild)
+}
+
+func TestMarkTaskCompleted_SpawnsNextOccurrence(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	now := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	due := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{ID: 4, Title: "Weekly report", DueDate: &due, DueTimezone: "UTC", Recurrence: "FREQ=WEEKLY"}
+	completed := task
+	completed.SetStatus(domain.StatusDone, now)
+
+	mockRepo.On("FindByID", uint(4)).Return(task, nil)
+	mockRepo.On("FindChildren", uint(4)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", mock.Anything).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", completed).Return(completed, nil)
+	mockRepo.On("FindSeries", uint(4)).Return([]domain.Task{completed}, nil)
+	mockRepo.On("Save", mock.Anything).Return(uint(5), nil)
+
+	_, err := service.MarkTaskCompleted(4, false)
+
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Save", mock.MatchedBy(func(next domain.Task) bool {
+		return next.SeriesID != nil && *next.SeriesID == 4 &&
+			next.DueDate.Equal(time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)) &&
+			next.Recurrence == "FREQ=WEEKLY" && next.Status == domain.StatusTodo && !next.Completed
+	}))
+}
+
+func TestMarkTaskCompleted_ReopenedOccurrenceSpawnsNoDuplicate(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// Task 4 was completed once, spawning task 5, and then reopened
+	due := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	seriesID := uint(4)
+	task := domain.Task{ID: 4, Title: "Weekly report", DueDate: &due, Recurrence: "FREQ=WEEKLY", Status: domain.StatusTodo}
+	completed := task
+	completed.SetStatus(domain.StatusDone, now)
+
+	mockRepo.On("FindByID", uint(4)).Return(task, nil)
+	mockRepo.On("FindChildren", uint(4)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", mock.Anything).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", completed).Return(completed, nil)
+	mockRepo.On("FindSeries", uint(4)).Return([]domain.Task{completed, {ID: 5, SeriesID: &seriesID, Recurrence: "FREQ=WEEKLY"}}, nil)
+
+	_, err := service.MarkTaskCompleted(4, false)
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestSetTaskStatus_CancelledOccurrenceSpawnsNothing(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	task := domain.Task{ID: 4, Title: "Weekly report", Recurrence: "FREQ=WEEKLY", Status: domain.StatusTodo}
+	cancelled := task
+	cancelled.SetStatus(domain.StatusCancelled, now)
+
+	mockRepo.On("FindByID", uint(4)).Return(task, nil)
+	mockRepo.On("FindChildren", uint(4)).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", cancelled).Return(cancelled, nil)
+
+	result, err := service.SetTaskStatus(4, domain.StatusCancelled)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusCancelled, result.Status)
+	mockRepo.AssertNotCalled(t, "FindSeries", mock.Anything)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestMarkTaskCompleted_SeriesCountReached(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	seriesID := uint(1)
+	due := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{ID: 2, Title: "Cert rotation", DueDate: &due, Recurrence: "FREQ=MONTHLY;COUNT=2", SeriesID: &seriesID}
+	completed := task
+	completed.SetStatus(domain.StatusDone, now)
+
+	mockRepo.On("FindByID", uint(2)).Return(task, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", mock.Anything).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", completed).Return(completed, nil)
+	mockRepo.On("FindSeries", uint(1)).Return([]domain.Task{{ID: 1, Completed: true}, completed}, nil)
+
+	_, err := service.MarkTaskCompleted(2, false)
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestSetRecurrence_StopsOpenOccurrences(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	seriesID := uint(1)
+	open := domain.Task{ID: 2, Recurrence: "FREQ=DAILY", SeriesID: &seriesID}
+	stopped := open
+	stopped.Recurrence = ""
+
+	mockRepo.On("FindByID", uint(2)).Return(open, nil)
+	mockRepo.On("FindSeries", uint(1)).Return([]domain.Task{{ID: 1, Completed: true, Recurrence: "FREQ=DAILY"}, open}, nil)
+	mockRepo.On("Update", stopped).Return(stopped, nil)
+
+	result, err := service.SetRecurrence(2, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", result.Recurrence)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestSetRecurrence_InvalidRule(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.SetRecurrence(1, "FREQ=FORTNIGHTLY")
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestSetParent_RejectsCycle(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	// Task 1 is the parent of task 2, so task 1 cannot move below task 2
+	one := uint(1)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &one}, nil)
+
+	two := uint(2)
+	_, err := service.SetParent(1, &two)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSetParent_MissingParent(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindByID", uint(9)).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	nine := uint(9)
+	_, err := service.SetParent(1, &nine)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestSetParent_UnderChildOfTrashedTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	// Task 3 is a live subtask of task 2, which is in the trash below task 1
+	one, two, three := uint(1), uint(2), uint(3)
+	mockRepo.On("FindByID", uint(5)).Return(domain.Task{ID: 5}, nil)
+	mockRepo.On("FindByID", uint(3)).Return(domain.Task{ID: 3, ParentID: &two}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(domain.Task{}, domain.ErrTaskNotFound)
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &one}, nil)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("Update", domain.Task{ID: 5, ParentID: &three}).Return(domain.Task{ID: 5, ParentID: &three}, nil)
+
+	result, err := service.SetParent(5, &three)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &three, result.ParentID)
+
+	// The walk goes on through the trashed task, so moving task 1 below task 3 is still a cycle
+	_, err = service.SetParent(1, &three)
+
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestSetParent_TrashedParent(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	two := uint(2)
+	_, err := service.SetParent(1, &two)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "FindTrashedByID", mock.Anything)
+}
+
+func TestDeleteTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("Trash", uint(1), now).Return(nil)
+
+	err := service.DeleteTask(1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestReorderTask_NeedsOneAnchor(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	before, after := uint(2), uint(3)
+	_, err := service.ReorderTask(1, domain.TaskMove{Before: &before, After: &after})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReorderTask_After(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	after := uint(3)
+	moved := domain.Task{ID: 1, Position: 3*domain.PositionGap + domain.PositionGap/2}
+	mockRepo.On("Move", uint(1), uint(3), true).Return(moved, nil)
+
+	task, err := service.ReorderTask(1, domain.TaskMove{After: &after})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moved, task)
+}
+
+func TestArchiveTask_Open(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Status: domain.StatusInProgress}, nil)
+
+	_, err := service.ArchiveTask(1)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestArchiveTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	archived := domain.Task{ID: 1, Completed: true, Status: domain.StatusDone, ArchivedAt: &now}
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Completed: true, Status: domain.StatusDone}, nil)
+	mockRepo.On("Update", archived).Return(archived, nil)
+
+	task, err := service.ArchiveTask(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &now, task.ArchivedAt)
+}
+
+func TestSetTaskStatus_ReopeningUnarchives(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	archivedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Completed: true, Status: domain.StatusDone, CompletedAt: &archivedAt, ArchivedAt: &archivedAt}, nil)
+	reopened := domain.Task{ID: 1, Status: domain.StatusTodo}
+	mockRepo.On("Update", reopened).Return(reopened, nil)
+
+	_, err := service.SetTaskStatus(1, domain.StatusTodo)
+
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Update", reopened)
+}
+
+func TestRestoreTask_DetachesFromMissingParent(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	parentID := uint(1)
+	deletedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	restored := domain.Task{ID: 2}
+
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &parentID, DeletedAt: &deletedAt}, nil)
+	mockRepo.On("Restore", uint(2)).Return(nil)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{}, domain.ErrTaskNotFound)
+	mockRepo.On("Update", restored).Return(restored, nil)
+
+	task, err := service.RestoreTask(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, restored, task)
+}
+
+func TestPurgeTask_NotInTrash(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	err := service.PurgeTask(1)
+
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestPurgeTask_PromotesSubtasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	var hooked []uint
+	service := NewTaskService(mockRepo, WithDeleteHook(func(id uint) error {
+		hooked = append(hooked, id)
+		return nil
+	}))
+
+	grandparentID, parentID := uint(1), uint(2)
+	child := domain.Task{ID: 3, ParentID: &parentID}
+	promoted := domain.Task{ID: 3, ParentID: &grandparentID}
+
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &grandparentID}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{child}, nil)
+	mockRepo.On("FindTrashed").Return([]domain.Task{}, nil)
+	mockRepo.On("Update", promoted).Return(promoted, nil)
+	mockRepo.On("Delete", uint(2)).Return(nil)
+
+	err := service.PurgeTask(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, hooked)
+	mockRepo.AssertCalled(t, "Update", promoted)
+	mockRepo.AssertCalled(t, "Delete", uint(2))
+}
+
+func TestPurgeTask_PromotesTrashedSubtasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	grandparentID, parentID := uint(1), uint(2)
+	deletedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	child := domain.Task{ID: 3, ParentID: &parentID, DeletedAt: &deletedAt}
+	promoted := domain.Task{ID: 3, ParentID: &grandparentID, DeletedAt: &deletedAt}
+
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &grandparentID, DeletedAt: &deletedAt}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindTrashed").Return([]domain.Task{{ID: 2, ParentID: &grandparentID, DeletedAt: &deletedAt}, child}, nil)
+	mockRepo.On("FindTrashedByID", uint(3)).Return(child, nil)
+	mockRepo.On("Update", promoted).Return(promoted, nil)
+	mockRepo.On("Delete", uint(2)).Return(nil)
+
+	err := service.PurgeTask(2)
+
+	// The trashed subtask no longer points at the purged task, so restoring it keeps it under the grandparent
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Update", promoted)
+	mockRepo.AssertCalled(t, "Delete", uint(2))
+}
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	expired := now.AddDate(0, 0, -31)
+	recent := now.AddDate(0, 0, -2)
+	mockRepo.On("FindTrashed").Return([]domain.Task{{ID: 1, DeletedAt: &recent}, {ID: 2, DeletedAt: &expired}}, nil)
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, DeletedAt: &expired}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockRepo.On("Delete", uint(2)).Return(nil)
+
+	purged, err := service.PurgeExpiredTrash(30 * 24 * time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	mockRepo.AssertNotCalled(t, "Delete", uint(1))
+}
+
+func TestPurgeExpiredTrash_SkipsTasksGoneSinceListing(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	expired := now.AddDate(0, 0, -31)
+	mockRepo.On("FindTrashed").Return([]domain.Task{{ID: 1, DeletedAt: &expired}, {ID: 2, DeletedAt: &expired}}, nil)
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{}, domain.ErrTaskNotFound)
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, DeletedAt: &expired}, nil)
+	mockRepo.On("FindChildren", uint(2)).Return([]domain.Task{}, nil)
+	mockRepo.On("Delete", uint(2)).Return(nil)
+
+	purged, err := service.PurgeExpiredTrash(30 * 24 * time.Hour)
+
+	// Task 1 was restored before this run reached it, so only task 2 counts
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	mockRepo.AssertNotCalled(t, "Delete", uint(1))
+}
+
+func TestPurgeExpiredTrash_ListsTrashOnce(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// Task 3 is in the trash below task 2, which is below task 1; only 1 and 2 have expired
+	one, two := uint(1), uint(2)
+	expired := now.AddDate(0, 0, -31)
+	recent := now.AddDate(0, 0, -2)
+	mockRepo.On("FindTrashed").Return([]domain.Task{
+		{ID: 1, DeletedAt: &expired},
+		{ID: 2, ParentID: &one, DeletedAt: &expired},
+		{ID: 3, ParentID: &two, DeletedAt: &recent},
+	}, nil)
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{ID: 1, DeletedAt: &expired}, nil)
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, ParentID: &one, DeletedAt: &expired}, nil).Once()
+	mockRepo.On("FindTrashedByID", uint(2)).Return(domain.Task{ID: 2, DeletedAt: &expired}, nil).Once()
+	mockRepo.On("FindTrashedByID", uint(3)).Return(domain.Task{ID: 3, ParentID: &two, DeletedAt: &recent}, nil)
+	mockRepo.On("FindChildren", mock.Anything).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", domain.Task{ID: 2, DeletedAt: &expired}).Return(domain.Task{ID: 2, DeletedAt: &expired}, nil)
+	mockRepo.On("Update", domain.Task{ID: 3, DeletedAt: &recent}).Return(domain.Task{ID: 3, DeletedAt: &recent}, nil)
+	mockRepo.On("Delete", mock.Anything).Return(nil)
+
+	purged, err := service.PurgeExpiredTrash(30 * 24 * time.Hour)
+
+	// Moving task 2 up is kept track of, so purging it then moves task 3 up to the top level
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	mockRepo.AssertNumberOfCalls(t, "FindTrashed", 1)
+	mockRepo.AssertCalled(t, "Update", domain.Task{ID: 3, DeletedAt: &recent})
+}
+
+func TestAddBlocker_Cycle(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	// 7 is already waiting on 12 through 9, so 12 cannot wait on 7
+	mockRepo.On("FindByID", uint(12)).Return(domain.Task{ID: 12}, nil)
+	mockRepo.On("FindByID", uint(7)).Return(domain.Task{ID: 7}, nil)
+	mockRepo.On("FindBlockers", uint(7)).Return([]domain.Task{{ID: 9}}, nil)
+	mockRepo.On("FindBlockers", uint(9)).Return([]domain.Task{{ID: 12}}, nil)
+
+	_, err := service.AddBlocker(12, 7)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "AddDependency", mock.Anything)
+}
+
+func TestAddBlocker_UnknownBlocker(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(12)).Return(domain.Task{ID: 12}, nil)
+	mockRepo.On("FindByID", uint(99)).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	_, err := service.AddBlocker(12, 99)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestAddBlocker(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("FindByID", uint(12)).Return(domain.Task{ID: 12}, nil)
+	mockRepo.On("FindByID", uint(7)).Return(domain.Task{ID: 7}, nil)
+	mockRepo.On("FindBlockers", uint(7)).Return([]domain.Task{}, nil)
+	mockRepo.On("AddDependency", domain.TaskDependency{TaskID: 12, BlockerID: 7, CreatedAt: now}).Return(nil)
+	mockRepo.On("FindBlockers", uint(12)).Return([]domain.Task{{ID: 7}}, nil)
+
+	blockers, err := service.AddBlocker(12, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{{ID: 7}}, blockers)
+}
+
+func TestMarkTaskCompleted_OpenBlocker(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(12)).Return(domain.Task{ID: 12}, nil)
+	mockRepo.On("FindChildren", uint(12)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", uint(12)).Return([]domain.Task{{ID: 7, Completed: true}, {ID: 9}}, nil)
+
+	_, err := service.MarkTaskCompleted(12, false)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestAddChecklistItem(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	existing := domain.Task{ID: 1, Checklist: []domain.ChecklistItem{{ID: 4, TaskID: 1, Text: "Draft", Position: 0}}}
+	expected := domain.Task{ID: 1, Checklist: []domain.ChecklistItem{
+		{ID: 4, TaskID: 1, Text: "Draft", Position: 0},
+		{Text: "Review", Position: 1},
+	}}
+	mockRepo.On("FindByID", uint(1)).Return(existing, nil)
+	mockRepo.On("Update", expected).Return(expected, nil)
+
+	result, err := service.AddChecklistItem(1, "  Review ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestAddChecklistItem_EmptyText(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.AddChecklistItem(1, "   ")
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestMoveChecklistItem(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	existing := domain.Task{ID: 1, Checklist: []domain.ChecklistItem{
+		{ID: 1, Text: "a", Position: 0},
+		{ID: 2, Text: "b", Position: 1},
+		{ID: 3, Text: "c", Position: 2},
+	}}
+	expected := domain.Task{ID: 1, Checklist: []domain.ChecklistItem{
+		{ID: 3, Text: "c", Position: 0},
+		{ID: 1, Text: "a", Position: 1},
+		{ID: 2, Text: "b", Position: 2},
+	}}
+	mockRepo.On("FindByID", uint(1)).Return(existing, nil)
+	mockRepo.On("Update", expected).Return(expected, nil)
+
+	result, err := service.MoveChecklistItem(1, 3, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestMoveChecklistItem_UnknownItem(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.MoveChecklistItem(1, 9, 0)
+
+	assert.ErrorIs(t, err, domain.ErrChecklistItemNotFound)
+}
+
+func TestToggleChecklistItem_AutoCompletes(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, WithChecklistAutoComplete())
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	existing := domain.Task{ID: 1, Status: domain.StatusTodo, Checklist: []domain.ChecklistItem{{ID: 4, Text: "Draft"}}}
+	toggled := existing
+	toggled.Checklist = []domain.ChecklistItem{{ID: 4, Text: "Draft", Checked: true}}
+	completed := toggled
+	completed.SetStatus(domain.StatusDone, now)
+
+	mockRepo.On("FindByID", uint(1)).Return(existing, nil).Once()
+	mockRepo.On("Update", toggled).Return(toggled, nil)
+	mockRepo.On("FindByID", uint(1)).Return(toggled, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("Update", completed).Return(completed, nil)
+
+	result, err := service.ToggleChecklistItem(1, 4)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Completed)
+	assert.Equal(t, domain.StatusDone, result.Status)
+}
+
+func TestToggleChecklistItem_AutoCompleteBlocked(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, WithChecklistAutoComplete())
+
+	existing := domain.Task{ID: 1, Status: domain.StatusTodo, Checklist: []domain.ChecklistItem{{ID: 4, Text: "Draft"}}}
+	toggled := existing
+	toggled.Checklist = []domain.ChecklistItem{{ID: 4, Text: "Draft", Checked: true}}
+
+	mockRepo.On("FindByID", uint(1)).Return(existing, nil).Once()
+	mockRepo.On("Update", toggled).Return(toggled, nil)
+	mockRepo.On("FindByID", uint(1)).Return(toggled, nil)
+	mockRepo.On("FindChildren", uint(1)).Return([]domain.Task{}, nil)
+	mockRepo.On("FindBlockers", uint(1)).Return([]domain.Task{{ID: 7}}, nil)
+
+	result, err := service.ToggleChecklistItem(1, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, toggled, result)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestCreateTask_UnknownAssignee(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	service := NewTaskService(mockRepo, WithUsers(mockUsers))
+
+	creatorID, assigneeID := uint(1), uint(9)
+	mockUsers.On("FindByID", uint(1)).Return(domain.User{ID: 1}, nil)
+	mockUsers.On("FindByID", uint(9)).Return(domain.User{}, domain.ErrUserNotFound)
+
+	_, err := service.CreateTask(domain.Task{Title: "Ship", CreatorID: &creatorID, AssigneeID: &assigneeID})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAssignTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	service := NewTaskService(mockRepo, WithUsers(mockUsers))
+
+	userID := uint(2)
+	assigned := domain.Task{ID: 1, Title: "Ship", AssigneeID: &userID}
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Ship"}, nil)
+	mockUsers.On("FindByID", uint(2)).Return(domain.User{ID: 2}, nil)
+	mockRepo.On("Update", assigned).Return(assigned, nil)
+
+	result, err := service.AssignTask(1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &userID, result.AssigneeID)
+}
+
+func TestAssignTask_UnknownUser(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	service := NewTaskService(mockRepo, WithUsers(mockUsers))
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockUsers.On("FindByID", uint(2)).Return(domain.User{}, domain.ErrUserNotFound)
+
+	_, err := service.AssignTask(1, 2)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUnassignTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	userID := uint(2)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, AssigneeID: &userID}, nil)
+	mockRepo.On("Update", domain.Task{ID: 1}).Return(domain.Task{ID: 1}, nil)
+
+	result, err := service.UnassignTask(1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.AssigneeID)
+}
+
+func TestStartTimer_AlreadyRunning(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockEntries := new(MockTimeEntryRepository)
+	service := NewTaskService(mockRepo, WithTimeTracking(mockEntries))
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	userID := uint(3)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockEntries.On("Start", domain.TimeEntry{TaskID: 1, UserID: &userID, StartedAt: now}).Return(uint(0), domain.ErrTimerRunning)
+
+	_, err := service.StartTimer(1, 3)
+
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestStopTimer(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockEntries := new(MockTimeEntryRepository)
+	service := NewTaskService(mockRepo, WithTimeTracking(mockEntries))
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	userID := uint(3)
+	running := domain.TimeEntry{ID: 5, TaskID: 1, UserID: &userID, StartedAt: now.Add(-90 * time.Minute)}
+	stopped := running
+	stopped.EndedAt = &now
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockEntries.On("FindRunning", uint(3)).Return(running, nil)
+	mockEntries.On("Update", stopped).Return(stopped, nil)
+
+	result, err := service.StopTimer(1, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, result.Duration(now))
+}
+
+func TestStopTimer_RunningOnOtherTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockEntries := new(MockTimeEntryRepository)
+	service := NewTaskService(mockRepo, WithTimeTracking(mockEntries))
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockEntries.On("FindRunning", uint(3)).Return(domain.TimeEntry{ID: 5, TaskID: 2}, nil)
+
+	_, err := service.StopTimer(1, 3)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockEntries.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestAddTimeEntry_EndsBeforeStart(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockEntries := new(MockTimeEntryRepository)
+	service := NewTaskService(mockRepo, WithTimeTracking(mockEntries))
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Minute)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.AddTimeEntry(1, domain.TimeEntry{StartedAt: start, EndedAt: &end})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockEntries.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGetProjectTimeTotals(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockEntries := new(MockTimeEntryRepository)
+	service := NewTaskService(mockRepo, WithTimeTracking(mockEntries))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	end := now.Add(-time.Hour)
+	mockRepo.On("FindByProject", uint(2)).Return([]domain.Task{{ID: 1, Estimate: 60}, {ID: 4, Estimate: 30}}, nil)
+	mockEntries.On("FindByProject", uint(2)).Return([]domain.TimeEntry{
+		{ID: 1, TaskID: 1, StartedAt: now.Add(-2 * time.Hour), EndedAt: &end},
+		{ID: 2, TaskID: 4, StartedAt: now.Add(-15 * time.Minute)},
+	}, nil)
+
+	totals, err := service.GetProjectTimeTotals(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TimeTotals{EstimateMinutes: 90, TrackedSeconds: 75 * 60, Entries: 2, Running: 1}, totals)
+}
+
+func TestUpdateTask_NotifiesDueDateHook(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	var rescheduled []uint
+	service := NewTaskService(mockRepo, WithDueDateHook(func(task domain.Task) error {
+		rescheduled = append(rescheduled, task.ID)
+		return nil
+	}))
+
+	due := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	updated := domain.Task{ID: 1, Title: "Ship", DueDate: &due}
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Ship"}, nil)
+	mockRepo.On("Update", mock.Anything).Return(updated, nil)
+
+	_, err := service.UpdateTask(1, domain.Task{Title: "Ship", DueDate: &due})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, rescheduled)
+}
+
+func TestSetFieldValue_WrongType(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockFields := new(MockCustomFieldRepository)
+	service := NewTaskService(mockRepo, WithCustomFields(mockFields))
+
+	projectID := uint(2)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, ProjectID: &projectID}, nil)
+	mockFields.On("FindByID", uint(3)).Return(domain.CustomField{ID: 3, ProjectID: 2, Type: domain.FieldNumber}, nil)
+
+	_, err := service.SetFieldValue(1, 3, "five")
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSetFieldValue_FieldFromOtherProject(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockFields := new(MockCustomFieldRepository)
+	service := NewTaskService(mockRepo, WithCustomFields(mockFields))
+
+	projectID := uint(2)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, ProjectID: &projectID}, nil)
+	mockFields.On("FindByID", uint(3)).Return(domain.CustomField{ID: 3, ProjectID: 7, Type: domain.FieldText}, nil)
+
+	_, err := service.SetFieldValue(1, 3, "hello")
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSetFieldValue_ReplacesValue(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockFields := new(MockCustomFieldRepository)
+	service := NewTaskService(mockRepo, WithCustomFields(mockFields))
+
+	projectID := uint(2)
+	old := "draft"
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, ProjectID: &projectID, FieldValues: []domain.FieldValue{{TaskID: 1, FieldID: 3, TextValue: &old}}}, nil)
+	mockFields.On("FindByID", uint(3)).Return(domain.CustomField{ID: 3, ProjectID: 2, Type: domain.FieldEnum, Options: []string{"draft", "final"}}, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(task domain.Task) bool {
+		return len(task.FieldValues) == 1 && *task.FieldValues[0].TextValue == "final"
+	})).Return(domain.Task{ID: 1}, nil)
+
+	_, err := service.SetFieldValue(1, 3, "final")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	actor := uint(7)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions)).As(&actor)
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Old", Description: "Same"}, nil)
+	mockRepo.On("Update", mock.Anything).Return(domain.Task{ID: 1, Title: "New", Description: "Same"}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.TaskID == 1 && revision.Action == domain.RevisionUpdate &&
+			revision.ActorID != nil && *revision.ActorID == actor &&
+			len(revision.Changes) == 1 && revision.Changes[0].Field == "title" &&
+			string(revision.Changes[0].Before) == `"Old"` && string(revision.Changes[0].After) == `"New"`
+	})).Return(uint(3), nil)
+
+	_, err := service.UpdateTask(1, domain.Task{Title: "New", Description: "Same"})
+
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestUpdateTask_UnchangedNotRecorded(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Same"}, nil)
+	mockRepo.On("Update", mock.Anything).Return(domain.Task{ID: 1, Title: "Same"}, nil)
+
+	_, err := service.UpdateTask(1, domain.Task{Title: "Same"})
+
+	assert.NoError(t, err)
+	mockRevisions.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRevertTask_UndoesLaterRevisions(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+
+	change := func(field, before, after string) domain.FieldChange {
+		return domain.FieldChange{Field: field, Before: json.RawMessage(before), After: json.RawMessage(after)}
+	}
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Third", Description: "edited", Status: domain.StatusDone, Completed: true}, nil)
+	mockRevisions.On("FindByTask", uint(1)).Return([]domain.Revision{
+		{ID: 1, TaskID: 1, Action: domain.RevisionCreate, Changes: []domain.FieldChange{change("title", `""`, `"First"`), change("description", `""`, `"original"`)}},
+		{ID: 2, TaskID: 1, Action: domain.RevisionUpdate, Changes: []domain.FieldChange{change("title", `"First"`, `"Second"`)}},
+		{ID: 3, TaskID: 1, Action: domain.RevisionUpdate, Changes: []domain.FieldChange{change("title", `"Second"`, `"Third"`), change("description", `"original"`, `"edited"`)}},
+		{ID: 4, TaskID: 1, Action: domain.RevisionComplete, Changes: []domain.FieldChange{change("status", `"todo"`, `"done"`), change("completed", `false`, `true`)}},
+	}, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(task domain.Task) bool {
+		// Content goes back to revision 1; the status change is left alone
+		return task.Title == "First" && task.Description == "original" && task.Status == domain.StatusDone
+	})).Return(domain.Task{ID: 1, Title: "First", Description: "original", Status: domain.StatusDone, Completed: true}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.Action == domain.RevisionRevert && len(revision.Changes) == 2
+	})).Return(uint(5), nil)
+
+	task, err := service.RevertTask(1, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "First", task.Title)
+	mockRepo.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestRevertTask_UnknownRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRevisions.On("FindByTask", uint(1)).Return([]domain.Revision{{ID: 1, TaskID: 1}}, nil)
+
+	_, err := service.RevertTask(1, 9)
+
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Old"}, nil)
+	mockRepo.On("Trash", uint(1), now).Return(nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.Action == domain.RevisionDelete && len(revision.Changes) == 1 &&
+			revision.Changes[0].Field == "deleted_at" &&
+			string(revision.Changes[0].Before) == `null` &&
+			string(revision.Changes[0].After) == `"2024-05-01T09:00:00Z"`
+	})).Return(uint(2), nil)
+
+	err := service.DeleteTask(1)
+
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestRestoreTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+
+	deletedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo.On("FindTrashedByID", uint(1)).Return(domain.Task{ID: 1, Title: "Old", DeletedAt: &deletedAt}, nil)
+	mockRepo.On("Restore", uint(1)).Return(nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.Action == domain.RevisionRestore && len(revision.Changes) == 1 &&
+			revision.Changes[0].Field == "deleted_at" &&
+			string(revision.Changes[0].Before) == `"2024-05-01T09:00:00Z"` &&
+			string(revision.Changes[0].After) == `null`
+	})).Return(uint(2), nil)
+
+	task, err := service.RestoreTask(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Task{ID: 1, Title: "Old"}, task)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestAssignTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	actor := uint(7)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions)).As(&actor)
+
+	userID := uint(5)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1}, nil)
+	mockRepo.On("Update", domain.Task{ID: 1, AssigneeID: &userID}).Return(domain.Task{ID: 1, AssigneeID: &userID}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.TaskID == 1 && revision.Action == domain.RevisionUpdate &&
+			revision.ActorID != nil && *revision.ActorID == actor &&
+			len(revision.Changes) == 1 && revision.Changes[0].Field == "assignee_id" &&
+			string(revision.Changes[0].Before) == `null` && string(revision.Changes[0].After) == `5`
+	})).Return(uint(3), nil)
+
+	_, err := service.AssignTask(1, userID)
+
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestArchiveTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Completed: true, Status: domain.StatusDone}, nil)
+	mockRepo.On("Update", mock.Anything).Return(domain.Task{ID: 1, Completed: true, Status: domain.StatusDone, ArchivedAt: &now}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.TaskID == 1 && revision.Action == domain.RevisionUpdate &&
+			len(revision.Changes) == 1 && revision.Changes[0].Field == "archived_at" &&
+			string(revision.Changes[0].Before) == `null`
+	})).Return(uint(3), nil)
+
+	_, err := service.ArchiveTask(1)
+
+	assert.NoError(t, err)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestArchiveCompletedBefore_RecordsRevisions(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+	now := time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	before := now.AddDate(0, 0, -30)
+	old := before.AddDate(0, 0, -1)
+	mockRepo.On("ArchiveCompletedBefore", before, now).Return([]domain.Task{
+		{ID: 1, Completed: true, CompletedAt: &old, ArchivedAt: &now},
+	}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.TaskID == 1 && len(revision.Changes) == 1 && revision.Changes[0].Field == "archived_at" &&
+			string(revision.Changes[0].Before) == `null`
+	})).Return(uint(3), nil)
+
+	archived, err := service.ArchiveCompletedBefore(before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	mockRepo.AssertNotCalled(t, "FindAll")
+	mockRevisions.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestRevertTask_UndoesAssignmentAndArchiving(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRevisions := new(MockRevisionRepository)
+	service := NewTaskService(mockRepo, WithRevisions(mockRevisions))
+
+	change := func(field, before, after string) domain.FieldChange {
+		return domain.FieldChange{Field: field, Before: json.RawMessage(before), After: json.RawMessage(after)}
+	}
+	assignee := uint(5)
+	mockRepo.On("FindByID", uint(1)).Return(domain.Task{ID: 1, Title: "Report", AssigneeID: &assignee}, nil)
+	mockRevisions.On("FindByTask", uint(1)).Return([]domain.Revision{
+		{ID: 1, TaskID: 1, Action: domain.RevisionUpdate, Changes: []domain.FieldChange{change("archived_at", `null`, `"2024-05-01T09:00:00Z"`)}},
+		{ID: 2, TaskID: 1, Action: domain.RevisionStatus, Changes: []domain.FieldChange{change("archived_at", `"2024-05-01T09:00:00Z"`, `null`)}},
+		{ID: 3, TaskID: 1, Action: domain.RevisionUpdate, Changes: []domain.FieldChange{change("assignee_id", `null`, `5`)}},
+	}, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(task domain.Task) bool {
+		// The assignment is undone, but the reopened task is not archived again
+		return task.AssigneeID == nil && task.ArchivedAt == nil
+	})).Return(domain.Task{ID: 1, Title: "Report"}, nil)
+	mockRevisions.On("Save", mock.MatchedBy(func(revision domain.Revision) bool {
+		return revision.Action == domain.RevisionRevert && len(revision.Changes) == 1 && revision.Changes[0].Field == "assignee_id"
+	})).Return(uint(4), nil)
+
+	_, err := service.RevertTask(1, 1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestQueryTasks_Defaults(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	mockRepo.On("Query", domain.TaskQuery{Sort: domain.SortByCreated, Limit: DefaultPageSize}).Return(domain.TaskPage{Tasks: []domain.Task{}}, nil)
+
+	_, err := service.QueryTasks(domain.TaskQuery{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestQueryTasks_Invalid(t *testing.T) {
+	from := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	tests := []struct {
+		name  string
+		query domain.TaskQuery
+	}{
+		{"unknown sort", domain.TaskQuery{Sort: "due_date"}},
+		{"limit too large", domain.TaskQuery{Limit: MaxPageSize + 1}},
+		{"negative limit", domain.TaskQuery{Limit: -1}},
+		{"empty created range", domain.TaskQuery{CreatedAfter: &from, CreatedBefore: &to}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			service := NewTaskService(mockRepo)
+
+			_, err := service.QueryTasks(tt.query)
+
+			assert.ErrorIs(t, err, ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "Query", mock.Anything)
+		})
+	}
+}
+
+func TestSearchTasks_NeedsWords(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	_, err := service.SearchTasks(" ?! ", 0)
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestSearchTasks_Highlights(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo)
+
+	description := strings.Repeat("filler ", 40) + "see the <b>Report</b> draft" + strings.Repeat(" tail", 40)
+	mockRepo.On("Search", []string{"rep"}, DefaultPageSize).Return([]domain.SearchHit{
+		{Task: domain.Task{ID: 1, Title: "Quarterly report & review", Description: description}, Score: 2},
+	}, nil)
+
+	hits, err := service.SearchTasks("Rep", 0)
 
 	assert.NoError(t, err)
-	mockRepo.AssertCalled(t, "Delete", uint(1))
+	assert.Equal(t, "Quarterly <mark>report</mark> &amp; review", hits[0].Highlights.Title)
+	snippet := hits[0].Highlights.Description
+	assert.Contains(t, snippet, "&lt;b&gt;<mark>Report</mark>&lt;/b&gt;")
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	// Ellipses aside, the snippet is cut to snippetLength characters before highlighting
+	assert.LessOrEqual(t, utf8.RuneCountInString(strings.Trim(html.UnescapeString(strings.NewReplacer("<mark>", "", "</mark>", "").Replace(snippet)), "…")), snippetLength)
 }
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// SetTaskStatus moves a task to another status of the workflow. Finishing a
+// task this way is subject to the same subtask rule as MarkTaskCompleted.
+func (s *TaskService) SetTaskStatus(id uint, status domain.Status) (domain.Task, error) {
+	if !status.Valid() {
+		return domain.Task{}, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, status)
+	}
+	if status == domain.StatusDone {
+		return s.MarkTaskCompleted(id, false)
+	}
+
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if status.Terminal() {
+		open, err := s.openDescendants(id)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if len(open) > 0 {
+			return domain.Task{}, fmt.Errorf("%w: task has %d open subtasks", ErrConflict, len(open))
+		}
+	}
+	if err := s.checkTransition(task, status); err != nil {
+		return domain.Task{}, err
+	}
+	return s.transitionTask(task, status)
+}
+
+// checkTransition verifies that the workflow lets the task move to the given status
+func (s *TaskService) checkTransition(task domain.Task, status domain.Status) error {
+	from := task.CurrentStatus()
+	if !s.workflow.Allows(from, status) {
+		return fmt.Errorf("%w: task %d cannot move from %s to %s", ErrConflict, task.ID, from, status)
+	}
+	return nil
+}
+
+// transitionTask stores the task in its new status and, when a recurring task
+// is completed, schedules its next occurrence. A cancelled occurrence ends the
+// series there.
+func (s *TaskService) transitionTask(task domain.Task, status domain.Status) (domain.Task, error) {
+	previous := task
+	wasTerminal := task.CurrentStatus().Terminal()
+	task.SetStatus(status, s.now())
+	action := domain.RevisionStatus
+	if status == domain.StatusDone {
+		action = domain.RevisionComplete
+	}
+	task, err := s.saveChange(s.repo, previous, task, action)
+	if err != nil {
+		return task, err
+	}
+	if status == domain.StatusDone && !wasTerminal {
+		if err := s.spawnNextOccurrence(task); err != nil {
+			return task, err
+		}
+	}
+	return task, nil
+}
+
+// validateInitialStatus defaults a new task to todo and rejects unknown or finished statuses
+func validateInitialStatus(task *domain.Task) error {
+	if task.Status == "" {
+		task.Status = domain.StatusTodo
+	}
+	if !task.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidInput, task.Status)
+	}
+	if task.Status.Terminal() {
+		return fmt.Errorf("%w: a new task cannot start as %s", ErrInvalidInput, task.Status)
+	}
+	return nil
+}
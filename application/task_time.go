@@ -0,0 +1,203 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxTimeEntryNoteLength matches the size of the time_entries.note column
+const maxTimeEntryNoteLength = 255
+
+// errTimeTrackingDisabled is returned by the time tracking methods of a
+// TaskService built without WithTimeTracking
+var errTimeTrackingDisabled = errors.New("time tracking is not configured")
+
+// WithTimeTracking enables timers and time entries on tasks
+func WithTimeTracking(entries domain.TimeEntryRepository) TaskServiceOption {
+	return func(s *TaskService) {
+		s.timeEntries = entries
+	}
+}
+
+// StartTimer starts timing a user's work on a task. A user can only have one
+// timer running at a time.
+func (s *TaskService) StartTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	if s.timeEntries == nil {
+		return domain.TimeEntry{}, errTimeTrackingDisabled
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+	if task.Completed {
+		return domain.TimeEntry{}, fmt.Errorf("%w: task is already %s", ErrConflict, task.CurrentStatus())
+	}
+	if s.users != nil {
+		if err := checkUser(s.users, &userID); err != nil {
+			return domain.TimeEntry{}, err
+		}
+	}
+
+	entry := domain.TimeEntry{TaskID: taskID, UserID: &userID, StartedAt: s.now()}
+	id, err := s.timeEntries.Start(entry)
+	if errors.Is(err, domain.ErrTimerRunning) {
+		return domain.TimeEntry{}, fmt.Errorf("%w: %v", ErrConflict, err)
+	} else if err != nil {
+		return domain.TimeEntry{}, err
+	}
+	entry.ID = id
+	return entry, nil
+}
+
+// StopTimer stops the user's running timer on a task.
+func (s *TaskService) StopTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	if s.timeEntries == nil {
+		return domain.TimeEntry{}, errTimeTrackingDisabled
+	}
+	if _, err := s.repo.FindByID(taskID); err != nil {
+		return domain.TimeEntry{}, err
+	}
+	entry, err := s.timeEntries.FindRunning(userID)
+	if errors.Is(err, domain.ErrTimeEntryNotFound) || (err == nil && entry.TaskID != taskID) {
+		return domain.TimeEntry{}, fmt.Errorf("%w: no timer running on task %d", ErrConflict, taskID)
+	} else if err != nil {
+		return domain.TimeEntry{}, err
+	}
+
+	now := s.now()
+	entry.EndedAt = &now
+	return s.timeEntries.Update(entry)
+}
+
+// AddTimeEntry logs time spent on a task by hand. The entry must have both a
+// start and an end, and must not end in the future.
+func (s *TaskService) AddTimeEntry(taskID uint, input domain.TimeEntry) (domain.TimeEntry, error) {
+	if s.timeEntries == nil {
+		return domain.TimeEntry{}, errTimeTrackingDisabled
+	}
+	if _, err := s.repo.FindByID(taskID); err != nil {
+		return domain.TimeEntry{}, err
+	}
+	if input.StartedAt.IsZero() || input.EndedAt == nil {
+		return domain.TimeEntry{}, fmt.Errorf("%w: time entry needs a start and an end", ErrInvalidInput)
+	}
+	if !input.EndedAt.After(input.StartedAt) {
+		return domain.TimeEntry{}, fmt.Errorf("%w: time entry must end after it starts", ErrInvalidInput)
+	}
+	if input.EndedAt.After(s.now()) {
+		return domain.TimeEntry{}, fmt.Errorf("%w: time entry must not end in the future", ErrInvalidInput)
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxTimeEntryNoteLength {
+		return domain.TimeEntry{}, fmt.Errorf("%w: time entry note must be at most %d characters", ErrInvalidInput, maxTimeEntryNoteLength)
+	}
+	if s.users != nil {
+		if err := checkUser(s.users, input.UserID); err != nil {
+			return domain.TimeEntry{}, err
+		}
+	}
+
+	entry := domain.TimeEntry{
+		TaskID:    taskID,
+		UserID:    input.UserID,
+		StartedAt: input.StartedAt,
+		EndedAt:   input.EndedAt,
+		Note:      note,
+		Manual:    true,
+	}
+	id, err := s.timeEntries.Save(entry)
+	entry.ID = id
+	return entry, err
+}
+
+// GetTimeEntries retrieves the time entries of a task, oldest first.
+func (s *TaskService) GetTimeEntries(taskID uint) ([]domain.TimeEntry, error) {
+	if s.timeEntries == nil {
+		return nil, errTimeTrackingDisabled
+	}
+	if _, err := s.repo.FindByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.timeEntries.FindByTask(taskID)
+}
+
+// DeleteTimeEntry removes a time entry from a task.
+func (s *TaskService) DeleteTimeEntry(taskID, entryID uint) error {
+	if s.timeEntries == nil {
+		return errTimeTrackingDisabled
+	}
+	entry, err := s.timeEntries.FindByID(entryID)
+	if err != nil {
+		return err
+	}
+	if entry.TaskID != taskID {
+		return domain.ErrTimeEntryNotFound
+	}
+	return s.timeEntries.Delete(entryID)
+}
+
+// GetTaskTimeTotals compares a task's estimate with the time tracked on it.
+func (s *TaskService) GetTaskTimeTotals(taskID uint) (domain.TimeTotals, error) {
+	if s.timeEntries == nil {
+		return domain.TimeTotals{}, errTimeTrackingDisabled
+	}
+	task, err := s.repo.FindByID(taskID)
+	if err != nil {
+		return domain.TimeTotals{}, err
+	}
+	entries, err := s.timeEntries.FindByTask(taskID)
+	if err != nil {
+		return domain.TimeTotals{}, err
+	}
+	return s.timeTotals([]domain.Task{task}, entries), nil
+}
+
+// GetProjectTimeTotals compares the summed estimates of a project's tasks with
+// the time tracked on them.
+func (s *TaskService) GetProjectTimeTotals(projectID uint) (domain.TimeTotals, error) {
+	if s.timeEntries == nil {
+		return domain.TimeTotals{}, errTimeTrackingDisabled
+	}
+	if s.projects != nil {
+		if _, err := s.projects.FindByID(projectID); err != nil {
+			return domain.TimeTotals{}, err
+		}
+	}
+	tasks, err := s.repo.FindByProject(projectID)
+	if err != nil {
+		return domain.TimeTotals{}, err
+	}
+	entries, err := s.timeEntries.FindByProject(projectID)
+	if err != nil {
+		return domain.TimeTotals{}, err
+	}
+	return s.timeTotals(tasks, entries), nil
+}
+
+// timeTotals sums the estimates of the tasks and the durations of the entries
+func (s *TaskService) timeTotals(tasks []domain.Task, entries []domain.TimeEntry) domain.TimeTotals {
+	now := s.now()
+	var totals domain.TimeTotals
+	for _, task := range tasks {
+		totals.EstimateMinutes += task.Estimate
+	}
+	for _, entry := range entries {
+		totals.TrackedSeconds += int64(entry.Duration(now).Seconds())
+		totals.Entries++
+		if entry.Running() {
+			totals.Running++
+		}
+	}
+	return totals
+}
+
+// validateEstimate rejects negative estimates
+func validateEstimate(minutes int) error {
+	if minutes < 0 {
+		return fmt.Errorf("%w: estimate must not be negative", ErrInvalidInput)
+	}
+	return nil
+}
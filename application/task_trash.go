@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// GetTrash retrieves the tasks in the trash, most recently deleted first.
+func (s *TaskService) GetTrash() ([]domain.Task, error) {
+	return s.repo.FindTrashed()
+}
+
+// RestoreTask takes a task back out of the trash. A task whose parent or
+// project is gone by then is restored at the top level or outside any project.
+func (s *TaskService) RestoreTask(id uint) (domain.Task, error) {
+	task, err := s.repo.FindTrashedByID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := s.repo.Restore(id); err != nil {
+		return domain.Task{}, err
+	}
+	trashed := task
+	task.DeletedAt = nil
+	previous := task
+	if err := s.record(id, domain.RevisionRestore, trashed, task); err != nil {
+		return domain.Task{}, err
+	}
+
+	detached := false
+	if task.ParentID != nil {
+		if _, err := s.repo.FindByID(*task.ParentID); errors.Is(err, domain.ErrTaskNotFound) {
+			task.ParentID = nil
+			detached = true
+		} else if err != nil {
+			return domain.Task{}, err
+		}
+	}
+	if task.ProjectID != nil && s.projects != nil {
+		if _, err := s.projects.FindByID(*task.ProjectID); errors.Is(err, domain.ErrProjectNotFound) {
+			placeInProject(&task, nil)
+			detached = true
+		} else if err != nil {
+			return domain.Task{}, err
+		}
+	}
+	if detached {
+		return s.saveChange(s.repo, previous, task, domain.RevisionUpdate)
+	}
+	return task, nil
+}
+
+// PurgeTask permanently deletes a task in the trash along with the data owned
+// by it. Its subtasks, trashed ones included, move up to the purged task's parent.
+func (s *TaskService) PurgeTask(id uint) error {
+	task, err := s.repo.FindTrashedByID(id)
+	if err != nil {
+		return err
+	}
+	trashed, err := s.repo.FindTrashed()
+	if err != nil {
+		return err
+	}
+	return s.purgeTask(task, trashed)
+}
+
+// purgeTask is PurgeTask with the trash listed beforehand, so purging many
+// tasks lists it only once. The trashed subtasks it moves up are updated in
+// the list too, for the tasks purged after it.
+func (s *TaskService) purgeTask(task domain.Task, trashed []domain.Task) error {
+	id := task.ID
+	children, err := s.repo.FindChildren(id)
+	if err != nil {
+		return err
+	}
+	for i, listed := range trashed {
+		if listed.ParentID == nil || *listed.ParentID != id {
+			continue
+		}
+		child, err := s.repo.FindTrashedByID(listed.ID)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			// Restored, and so among the live children, or purged since the trash was listed
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		trashed[i].ParentID = task.ParentID
+		children = append(children, child)
+	}
+	for _, child := range children {
+		previous := child
+		child.ParentID = task.ParentID
+		if _, err := s.saveChange(s.repo, previous, child, domain.RevisionUpdate); err != nil {
+			return err
+		}
+	}
+	for _, hook := range s.onDelete {
+		if err := hook(id); err != nil {
+			return err
+		}
+	}
+	return s.repo.Delete(id)
+}
+
+// PurgeExpiredTrash permanently deletes the tasks that have been in the trash
+// for longer than retention and reports how many were purged.
+func (s *TaskService) PurgeExpiredTrash(retention time.Duration) (int, error) {
+	trashed, err := s.repo.FindTrashed()
+	if err != nil {
+		return 0, err
+	}
+	cutoff := s.now().Add(-retention)
+	purged := 0
+	for _, task := range trashed {
+		if task.DeletedAt == nil || !task.DeletedAt.Before(cutoff) {
+			continue
+		}
+		// Read the task again, as it may have been purged or restored since the trash was listed
+		task, err := s.repo.FindTrashedByID(task.ID)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			continue
+		}
+		if err == nil {
+			err = s.purgeTask(task, trashed)
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// RunTrashPurge purges expired trash every interval until ctx is cancelled.
+func (s *TaskService) RunTrashPurge(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.PurgeExpiredTrash(retention); err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d tasks from the trash", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// maxUserNameLength matches the size of the users.name column
+const maxUserNameLength = 128
+
+type UserService struct {
+	repo     domain.UserRepository
+	taskRepo domain.TaskRepository
+}
+
+func NewUserService(repo domain.UserRepository, taskRepo domain.TaskRepository) *UserService {
+	return &UserService{repo: repo, taskRepo: taskRepo}
+}
+
+// CreateUser registers a new user. Email addresses must be unique.
+func (s *UserService) CreateUser(name, email string) (domain.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.User{}, fmt.Errorf("%w: user name must not be empty", ErrInvalidInput)
+	}
+	if len(name) > maxUserNameLength {
+		return domain.User{}, fmt.Errorf("%w: user name must be at most %d characters", ErrInvalidInput, maxUserNameLength)
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if _, err := s.repo.FindByEmail(email); err == nil {
+		return domain.User{}, fmt.Errorf("%w: email %q is already registered", ErrConflict, email)
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return domain.User{}, err
+	}
+
+	user := domain.User{Name: name, Email: email, CreatedAt: time.Now()}
+	id, err := s.repo.Save(user)
+	user.ID = id
+	return user, err
+}
+
+// GetAllUsers retrieves all users.
+func (s *UserService) GetAllUsers() ([]domain.User, error) {
+	return s.repo.FindAll()
+}
+
+// GetUser retrieves a user by their ID.
+func (s *UserService) GetUser(id uint) (domain.User, error) {
+	return s.repo.FindByID(id)
+}
+
+// DeleteUser removes a user. Users that still have tasks assigned cannot be deleted.
+func (s *UserService) DeleteUser(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	tasks, err := s.taskRepo.FindByAssignee(id)
+	if err != nil {
+		return err
+	}
+	if len(tasks) > 0 {
+		return fmt.Errorf("%w: user still has %d assigned tasks", ErrConflict, len(tasks))
+	}
+	return s.repo.Delete(id)
+}
+
+// checkUser verifies that the given user, when set, exists
+func checkUser(repo domain.UserRepository, userID *uint) error {
+	if userID == nil {
+		return nil
+	}
+	if _, err := repo.FindByID(*userID); errors.Is(err, domain.ErrUserNotFound) {
+		return fmt.Errorf("%w: user %d does not exist", ErrInvalidInput, *userID)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeEmail trims and lower-cases an email address and checks it is well formed
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("%w: invalid email address %q", ErrInvalidInput, email)
+	}
+	return email, nil
+}
@@ -0,0 +1,11 @@
+package application
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// UserServiceInterface defines the methods for managing the users tasks can be assigned to.
+type UserServiceInterface interface {
+	CreateUser(name, email string) (domain.User, error)
+	GetAllUsers() ([]domain.User, error)
+	GetUser(id uint) (domain.User, error)
+	DeleteUser(id uint) error
+}
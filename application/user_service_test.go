@@ -0,0 +1,90 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserRepository is a mock implementation of the UserRepository interface
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Save(user domain.User) (uint, error) {
+	args := m.Called(user)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (domain.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (domain.User, error) {
+	args := m.Called(email)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindAll() ([]domain.User, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestCreateUser_NormalizesEmail(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByEmail", "ada@example.com").Return(domain.User{}, domain.ErrUserNotFound)
+	mockRepo.On("Save", mock.AnythingOfType("domain.User")).Return(uint(1), nil)
+
+	user, err := service.CreateUser(" Ada ", " Ada@Example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), user.ID)
+	assert.Equal(t, "Ada", user.Name)
+	assert.Equal(t, "ada@example.com", user.Email)
+}
+
+func TestCreateUser_DuplicateEmail(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserService(mockRepo, new(MockTaskRepository))
+
+	mockRepo.On("FindByEmail", "ada@example.com").Return(domain.User{ID: 1, Email: "ada@example.com"}, nil)
+
+	_, err := service.CreateUser("Ada", "ada@example.com")
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCreateUser_InvalidEmail(t *testing.T) {
+	service := NewUserService(new(MockUserRepository), new(MockTaskRepository))
+
+	_, err := service.CreateUser("Ada", "Ada <ada@example.com>")
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestDeleteUser_HasAssignedTasks(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewUserService(mockRepo, mockTaskRepo)
+
+	userID := uint(1)
+	mockRepo.On("FindByID", uint(1)).Return(domain.User{ID: 1}, nil)
+	mockTaskRepo.On("FindByAssignee", uint(1)).Return([]domain.Task{{ID: 4, AssigneeID: &userID}}, nil)
+
+	err := service.DeleteUser(1)
+
+	assert.ErrorIs(t, err, ErrConflict)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
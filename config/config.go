@@ -3,12 +3,18 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds the configuration values for the application
 type Config struct {
+	DBDriver    string // Database the tasks are stored in: mysql, postgres, sqlite, bolt or memory
+	DBPath      string // File the sqlite driver stores the database in, and the bolt and memory drivers everything but tasks
+	BoltPath    string // File the bolt driver stores tasks in
+	MemoryDir   string // Directory the memory driver keeps its task log and snapshots in
 	DBUser      string
 	DBPassword  string
 	DBHost      string
@@ -17,6 +23,23 @@ type Config struct {
 	DBCharset   string
 	DBParseTime string
 	DBLoc       string
+	DBSSLMode   string // sslmode of PostgreSQL connections; empty keeps the driver's default
+
+	SnapshotInterval time.Duration // How often the memory driver compacts its task log into a snapshot
+
+	AttachmentDir     string // Directory attachment files are stored in
+	AttachmentMaxSize int64  // Largest accepted attachment in bytes; 0 keeps the default
+
+	ChecklistAutoComplete bool // Complete a task once every checklist item is checked
+
+	TrashRetention time.Duration // How long deleted tasks stay in the trash before being purged; 0 keeps them
+
+	ReminderInterval   time.Duration // How often the reminder scheduler looks for due reminders
+	ReminderNotifier   string        // How reminders are delivered: log, webhook or smtp
+	ReminderWebhookURL string        // URL reminders are posted to by the webhook notifier
+	SMTPAddr           string        // host:port of the SMTP relay used by the smtp notifier
+	SMTPFrom           string        // Sender address of reminder mails
+	SMTPTo             string        // Recipient of reminders for tasks without an assignee
 }
 
 // Global variable to hold the loaded config
@@ -31,6 +54,10 @@ func LoadConfig() error {
 
 	// Read configuration values from environment variables
 	AppConfig = &Config{
+		DBDriver:    os.Getenv("DB_DRIVER"),
+		DBPath:      os.Getenv("DB_PATH"),
+		BoltPath:    os.Getenv("BOLT_PATH"),
+		MemoryDir:   os.Getenv("MEMORY_DIR"),
 		DBUser:      os.Getenv("DB_USER"),
 		DBPassword:  os.Getenv("DB_PASSWORD"),
 		DBHost:      os.Getenv("DB_HOST"),
@@ -39,11 +66,94 @@ func LoadConfig() error {
 		DBCharset:   os.Getenv("DB_CHARSET"),
 		DBParseTime: os.Getenv("DB_PARSE_TIME"),
 		DBLoc:       os.Getenv("DB_LOC"),
+		DBSSLMode:   os.Getenv("DB_SSLMODE"),
+
+		SnapshotInterval: 10 * time.Minute,
+
+		AttachmentDir: os.Getenv("ATTACHMENT_DIR"),
+
+		ReminderInterval:   time.Minute,
+		ReminderNotifier:   os.Getenv("REMINDER_NOTIFIER"),
+		ReminderWebhookURL: os.Getenv("REMINDER_WEBHOOK_URL"),
+		SMTPAddr:           os.Getenv("SMTP_ADDR"),
+		SMTPFrom:           os.Getenv("SMTP_FROM"),
+		SMTPTo:             os.Getenv("SMTP_TO"),
+	}
+	if AppConfig.AttachmentDir == "" {
+		AppConfig.AttachmentDir = "attachments"
+	}
+	if v := os.Getenv("ATTACHMENT_MAX_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid ATTACHMENT_MAX_BYTES %q", v)
+		}
+		AppConfig.AttachmentMaxSize = n
+	}
+	if v := os.Getenv("CHECKLIST_AUTO_COMPLETE"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid CHECKLIST_AUTO_COMPLETE %q", v)
+		}
+		AppConfig.ChecklistAutoComplete = enabled
+	}
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return fmt.Errorf("invalid TRASH_RETENTION_DAYS %q", v)
+		}
+		AppConfig.TrashRetention = time.Duration(days) * 24 * time.Hour
+	}
+	if v := os.Getenv("SNAPSHOT_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid SNAPSHOT_INTERVAL %q", v)
+		}
+		AppConfig.SnapshotInterval = interval
+	}
+	if v := os.Getenv("REMINDER_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid REMINDER_INTERVAL %q", v)
+		}
+		AppConfig.ReminderInterval = interval
+	}
+	switch AppConfig.ReminderNotifier {
+	case "":
+		AppConfig.ReminderNotifier = "log"
+	case "log":
+	case "webhook":
+		if AppConfig.ReminderWebhookURL == "" {
+			return fmt.Errorf("REMINDER_WEBHOOK_URL is required for the webhook notifier")
+		}
+	case "smtp":
+		if AppConfig.SMTPAddr == "" || AppConfig.SMTPFrom == "" {
+			return fmt.Errorf("SMTP_ADDR and SMTP_FROM are required for the smtp notifier")
+		}
+	default:
+		return fmt.Errorf("invalid REMINDER_NOTIFIER %q", AppConfig.ReminderNotifier)
 	}
 
 	// Ensure that all required values are set
-	if AppConfig.DBUser == "" || AppConfig.DBPassword == "" || AppConfig.DBHost == "" || AppConfig.DBPort == "" || AppConfig.DBName == "" {
-		return fmt.Errorf("missing required environment variables")
+	switch AppConfig.DBDriver {
+	case "":
+		AppConfig.DBDriver = "mysql"
+		fallthrough
+	case "mysql", "postgres":
+		if AppConfig.DBUser == "" || AppConfig.DBPassword == "" || AppConfig.DBHost == "" || AppConfig.DBPort == "" || AppConfig.DBName == "" {
+			return fmt.Errorf("missing required environment variables")
+		}
+	case "sqlite", "bolt", "memory":
+		if AppConfig.DBPath == "" {
+			AppConfig.DBPath = "todo.db"
+		}
+		if AppConfig.BoltPath == "" {
+			AppConfig.BoltPath = "tasks.bolt"
+		}
+		if AppConfig.MemoryDir == "" {
+			AppConfig.MemoryDir = "data"
+		}
+	default:
+		return fmt.Errorf("invalid DB_DRIVER %q", AppConfig.DBDriver)
 	}
 
 	return nil
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrAttachmentNotFound is returned when an attachment with the requested ID does not exist on the task
+var ErrAttachmentNotFound = fmt.Errorf("attachment %w", ErrNotFound)
+
+// ErrBlobNotFound is returned by blob storage when no content is stored under a key
+var ErrBlobNotFound = fmt.Errorf("blob %w", ErrNotFound)
+
+// Attachment describes a file uploaded to a task; its content lives in blob storage
+type Attachment struct {
+	ID          uint      `json:"id"`                            // Unique identifier
+	TaskID      uint      `json:"task_id" gorm:"not null;index"` // Task the file is attached to
+	FileName    string    `json:"file_name" gorm:"size:255"`     // Original name of the uploaded file
+	ContentType string    `json:"content_type" gorm:"size:128"`  // MIME type detected from the content
+	Size        int64     `json:"size"`                          // Size of the content in bytes
+	StorageKey  string    `json:"-" gorm:"size:255;uniqueIndex"` // Key of the content in blob storage
+	CreatedAt   time.Time `json:"created_at"`                    // Timestamp of the upload
+}
+
+// AttachmentRepository is an interface for interacting with attachment metadata storage
+type AttachmentRepository interface {
+	Save(attachment Attachment) (uint, error)
+	FindByID(id uint) (Attachment, error)
+	FindByTask(taskID uint) ([]Attachment, error)
+	Delete(id uint) error
+	DeleteByTask(taskID uint) error
+}
+
+// BlobStorage stores the content of attachments under opaque keys
+type BlobStorage interface {
+	Put(key string, content io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ErrChecklistItemNotFound is returned when a task has no checklist item with the requested ID
+var ErrChecklistItemNotFound = fmt.Errorf("checklist item %w", ErrNotFound)
+
+// ChecklistItem is one line of a task's inline checklist
+type ChecklistItem struct {
+	ID       uint   `json:"id"`                       // Unique identifier
+	TaskID   uint   `json:"-" gorm:"not null;index"`  // Task the item belongs to
+	Text     string `json:"text" gorm:"size:255"`     // What needs doing
+	Checked  bool   `json:"checked"`                  // Whether the item is done
+	Position int    `json:"position" gorm:"not null"` // Zero-based place of the item in the checklist
+}
+
+// ChecklistIndex returns the position in the checklist of the item with the given ID
+func (t Task) ChecklistIndex(itemID uint) (int, bool) {
+	for i, item := range t.Checklist {
+		if item.ID == itemID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// ChecklistProgress returns the percentage of checked items, rounded down.
+// It reports false for tasks without a checklist.
+func (t Task) ChecklistProgress() (int, bool) {
+	if len(t.Checklist) == 0 {
+		return 0, false
+	}
+	checked := 0
+	for _, item := range t.Checklist {
+		if item.Checked {
+			checked++
+		}
+	}
+	return checked * 100 / len(t.Checklist), true
+}
+
+// MarshalJSON adds the checklist progress to the task's fields
+func (t Task) MarshalJSON() ([]byte, error) {
+	type plainTask Task
+	out := struct {
+		plainTask
+		ChecklistProgress *int `json:"checklist_progress,omitempty"`
+	}{plainTask: plainTask(t)}
+	if progress, ok := t.ChecklistProgress(); ok {
+		out.ChecklistProgress = &progress
+	}
+	return json.Marshal(out)
+}
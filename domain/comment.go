@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrCommentNotFound is returned when a comment with the requested ID does not exist on the task
+var ErrCommentNotFound = fmt.Errorf("comment %w", ErrNotFound)
+
+// Comment is a message in the discussion thread of a task
+type Comment struct {
+	ID        uint      `json:"id"`                            // Unique identifier
+	TaskID    uint      `json:"task_id" gorm:"not null;index"` // Task the comment was posted on
+	Author    string    `json:"author" gorm:"size:128"`        // Name of whoever wrote the comment
+	Body      string    `json:"body" gorm:"type:text"`         // Text of the comment
+	CreatedAt time.Time `json:"created_at"`                    // Timestamp of when the comment was posted
+	UpdatedAt time.Time `json:"updated_at"`                    // Timestamp of the last edit
+	Edited    bool      `json:"edited"`                        // Whether the body was changed after posting
+}
+
+// CommentRepository is an interface for interacting with comment storage
+type CommentRepository interface {
+	Save(comment Comment) (uint, error)
+	FindByID(id uint) (Comment, error)
+	FindByTask(taskID uint) ([]Comment, error)
+	Update(comment Comment) (Comment, error)
+	Delete(id uint) error
+	DeleteByTask(taskID uint) error
+}
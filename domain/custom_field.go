@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ErrCustomFieldNotFound is returned when a project has no custom field with the requested ID
+var ErrCustomFieldNotFound = fmt.Errorf("custom field %w", ErrNotFound)
+
+// FieldDateLayout is how date field values are written
+const FieldDateLayout = "2006-01-02"
+
+// FieldType is the kind of value a custom field holds
+type FieldType string
+
+const (
+	FieldText   FieldType = "text"
+	FieldNumber FieldType = "number"
+	FieldDate   FieldType = "date"
+	FieldEnum   FieldType = "enum"
+)
+
+// Valid reports whether the field type is one of the known types
+func (t FieldType) Valid() bool {
+	switch t {
+	case FieldText, FieldNumber, FieldDate, FieldEnum:
+		return true
+	}
+	return false
+}
+
+// CustomField is a piece of metadata a project defines for its tasks
+type CustomField struct {
+	ID        uint      `json:"id"`                                                             // Unique identifier
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_custom_field_name"`   // Project the field belongs to
+	Name      string    `json:"name" gorm:"size:64;not null;uniqueIndex:idx_custom_field_name"` // Name of the field, unique within the project
+	Type      FieldType `json:"type" gorm:"size:16;not null"`                                   // Kind of value the field holds
+	Options   []string  `json:"options,omitempty" gorm:"serializer:json"`                       // Allowed values of an enum field
+	CreatedAt time.Time `json:"created_at"`                                                     // Timestamp of field creation
+}
+
+// FieldValue is a task's value for one custom field. Only the column matching
+// the field's type is set; enum values are stored as text.
+type FieldValue struct {
+	TaskID      uint       `gorm:"primaryKey;autoIncrement:false"`       // Task the value belongs to
+	FieldID     uint       `gorm:"primaryKey;autoIncrement:false;index"` // Custom field the value is for
+	TextValue   *string    `gorm:"size:255;index"`                       // Value of a text or enum field
+	NumberValue *float64   `gorm:"index"`                                // Value of a number field
+	DateValue   *time.Time `gorm:"index"`                                // Value of a date field, at midnight UTC
+}
+
+// Value returns the value as it is shown to clients
+func (v FieldValue) Value() any {
+	switch {
+	case v.TextValue != nil:
+		return *v.TextValue
+	case v.NumberValue != nil:
+		return *v.NumberValue
+	case v.DateValue != nil:
+		return v.DateValue.Format(FieldDateLayout)
+	}
+	return nil
+}
+
+// Equal reports whether two values are for the same field and hold the same value
+func (v FieldValue) Equal(other FieldValue) bool {
+	return v.FieldID == other.FieldID && v.Compare(other) == 0
+}
+
+// Compare orders two values of the same field, returning -1, 0 or +1
+func (v FieldValue) Compare(other FieldValue) int {
+	switch {
+	case v.TextValue != nil && other.TextValue != nil:
+		return cmp.Compare(*v.TextValue, *other.TextValue)
+	case v.NumberValue != nil && other.NumberValue != nil:
+		return cmp.Compare(*v.NumberValue, *other.NumberValue)
+	case v.DateValue != nil && other.DateValue != nil:
+		return v.DateValue.Compare(*other.DateValue)
+	}
+	return 0
+}
+
+// MarshalJSON writes the value as {"field_id": ..., "value": ...}
+func (v FieldValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		FieldID uint `json:"field_id"`
+		Value   any  `json:"value"`
+	}{v.FieldID, v.Value()})
+}
+
+// FieldValue returns the task's value for the given custom field
+func (t Task) FieldValue(fieldID uint) (FieldValue, bool) {
+	for _, value := range t.FieldValues {
+		if value.FieldID == fieldID {
+			return value, true
+		}
+	}
+	return FieldValue{}, false
+}
+
+// LessByField orders tasks by their value for the given custom field, with
+// tasks that have no value last and ties broken by ID. desc reverses the order
+// of the values only.
+func LessByField(a, b Task, fieldID uint, desc bool) bool {
+	va, okA := a.FieldValue(fieldID)
+	vb, okB := b.FieldValue(fieldID)
+	switch {
+	case okA && !okB:
+		return true
+	case !okA && okB:
+		return false
+	case okA && okB:
+		if c := va.Compare(vb); c != 0 {
+			return (c < 0) != desc
+		}
+	}
+	return a.ID < b.ID
+}
+
+// CustomFieldRepository is an interface for interacting with custom field storage
+type CustomFieldRepository interface {
+	Save(field CustomField) (uint, error)
+	FindByID(id uint) (CustomField, error)
+	FindByProject(projectID uint) ([]CustomField, error)
+	// Delete removes a field along with every task's value for it
+	Delete(id uint) error
+}
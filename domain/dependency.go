@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrDependencyNotFound is returned when a task is not blocked by the given task
+var ErrDependencyNotFound = fmt.Errorf("dependency %w", ErrNotFound)
+
+// TaskDependency records that a task cannot start until its blocker is done
+type TaskDependency struct {
+	TaskID    uint      `json:"task_id" gorm:"primaryKey"`          // Task that is blocked
+	BlockerID uint      `json:"blocker_id" gorm:"primaryKey;index"` // Task that has to be done first
+	CreatedAt time.Time `json:"created_at"`                         // Timestamp of when the dependency was added
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// DueLocation returns the timezone the task's due date is expressed in.
+// Tasks without a (valid) timezone fall back to the server's local time.
+func (t Task) DueLocation() *time.Location {
+	if t.DueTimezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(t.DueTimezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// Deadline returns the last moment at which the task is still on time.
+// Date-only due dates run until the end of that day in the task's timezone.
+func (t Task) Deadline() (time.Time, bool) {
+	if t.DueDate == nil {
+		return time.Time{}, false
+	}
+	if t.DueHasTime {
+		return *t.DueDate, true
+	}
+	loc := t.DueLocation()
+	y, m, d := t.DueDate.In(loc).Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond), true
+}
+
+// IsOverdue reports whether an open task has passed its deadline at the given time
+func (t Task) IsOverdue(now time.Time) bool {
+	deadline, ok := t.Deadline()
+	return ok && !t.Completed && now.After(deadline)
+}
+
+// IsDueOn reports whether the task's due date falls on the same calendar day
+// as the given time, as seen from the task's timezone
+func (t Task) IsDueOn(day time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	loc := t.DueLocation()
+	dy, dm, dd := t.DueDate.In(loc).Date()
+	y, m, d := day.In(loc).Date()
+	return dy == y && dm == m && dd == d
+}
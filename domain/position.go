@@ -0,0 +1,49 @@
+package domain
+
+// PositionGap is the spacing between the positions of neighbouring tasks. The
+// room it leaves lets a task be moved by renumbering only that task; the
+// whole list is respaced only once the gap between two tasks runs out.
+const PositionGap int64 = 1 << 16
+
+// TaskMove places a task directly before or directly after another task
+type TaskMove struct {
+	Before *uint `json:"before"` // Task to place the moved task in front of
+	After  *uint `json:"after"`  // Task to place the moved task behind
+}
+
+// Anchor returns the task the move is relative to and whether the moved task
+// goes after it. It reports false unless exactly one of Before and After is set.
+func (m TaskMove) Anchor() (id uint, after bool, ok bool) {
+	switch {
+	case m.Before != nil && m.After == nil:
+		return *m.Before, false, true
+	case m.After != nil && m.Before == nil:
+		return *m.After, true, true
+	}
+	return 0, false, false
+}
+
+// PositionBetween returns a position strictly between prev and next, either of
+// which may be nil at the ends of the list. It reports false when there is no
+// room left and the list needs respacing.
+func PositionBetween(prev, next *int64) (int64, bool) {
+	switch {
+	case prev == nil && next == nil:
+		return PositionGap, true
+	case prev == nil:
+		return *next - PositionGap, true
+	case next == nil:
+		return *prev + PositionGap, true
+	case *next-*prev < 2:
+		return 0, false
+	}
+	return *prev + (*next-*prev)/2, true
+}
+
+// LessByPosition orders tasks by their manual position, then by ID
+func LessByPosition(a, b Task) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.ID < b.ID
+}
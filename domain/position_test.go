@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestPositionBetween(t *testing.T) {
+	one, two, three := int64(1), int64(2), int64(3)
+	tests := []struct {
+		name       string
+		prev, next *int64
+		want       int64
+		wantOK     bool
+	}{
+		{"empty list", nil, nil, PositionGap, true},
+		{"front", nil, &two, two - PositionGap, true},
+		{"back", &two, nil, two + PositionGap, true},
+		{"between", &one, &three, 2, true},
+		{"no room", &one, &two, 0, false},
+		{"same position", &two, &two, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PositionBetween(tt.prev, tt.next)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PositionBetween() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "fmt"
+
+// Priority ranks how urgently a task needs attention. It is stored as an
+// integer so that higher priorities sort first, and serialized by name.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = [...]string{"none", "low", "medium", "high", "urgent"}
+
+// ParsePriority converts a priority name into a Priority
+func ParsePriority(name string) (Priority, error) {
+	for i, n := range priorityNames {
+		if n == name {
+			return Priority(i), nil
+		}
+	}
+	return PriorityNone, fmt.Errorf("unknown priority %q", name)
+}
+
+// Valid reports whether the priority is one of the known levels
+func (p Priority) Valid() bool {
+	return p >= PriorityNone && p <= PriorityUrgent
+}
+
+func (p Priority) String() string {
+	if !p.Valid() {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+// MarshalText encodes the priority by name
+func (p Priority) MarshalText() ([]byte, error) {
+	if !p.Valid() {
+		return nil, fmt.Errorf("invalid priority %d", int(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes a priority name; an empty string means no priority
+func (p *Priority) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = PriorityNone
+		return nil
+	}
+	parsed, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// LessByPriority orders tasks with the highest priority first, then by the
+// earliest due date (tasks without one last), then by ID
+func LessByPriority(a, b Task) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if c := compareDueDates(a.DueDate, b.DueDate); c != 0 {
+		return c < 0
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrProjectNotFound is returned when a project with the requested ID does not exist
+var ErrProjectNotFound = fmt.Errorf("project %w", ErrNotFound)
+
+// Project represents a list that owns a subset of the tasks
+type Project struct {
+	ID            uint      `json:"id"`                       // Unique identifier
+	Name          string    `json:"name" gorm:"size:128"`     // Name of the project
+	Description   string    `json:"description"`              // Detailed description of the project
+	CreatedAt     time.Time `json:"created_at"`               // Timestamp of project creation
+	TaskCount     int64     `json:"task_count" gorm:"-"`      // Number of tasks in the project
+	OpenTaskCount int64     `json:"open_task_count" gorm:"-"` // Number of incomplete tasks in the project
+}
+
+// TaskCounts holds the number of tasks, and of incomplete tasks, in a project
+type TaskCounts struct {
+	Total int64
+	Open  int64
+}
+
+// ProjectRepository is an interface for interacting with project storage
+type ProjectRepository interface {
+	Save(project Project) (uint, error)
+	FindByID(id uint) (Project, error)
+	FindAll() ([]Project, error)
+	Update(project Project) (Project, error)
+	Delete(id uint) error
+}
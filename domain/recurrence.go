@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the base repetition unit of a recurrence rule
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxRecurrenceSteps bounds the search for a month that contains the anchor day
+const maxRecurrenceSteps = 10000
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Recurrence is a parsed subset of an RFC 5545 RRULE: FREQ, INTERVAL,
+// BYDAY (weekly rules only), COUNT and UNTIL
+type Recurrence struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    *time.Time
+}
+
+// ParseRecurrence parses either a shorthand ("daily", "weekly", "monthly",
+// "yearly") or an RRULE such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimSpace(rule)
+	switch strings.ToLower(rule) {
+	case "daily", "weekly", "monthly", "yearly":
+		return Recurrence{Freq: Frequency(strings.ToUpper(rule)), Interval: 1}, nil
+	}
+
+	r := Recurrence{Interval: 1}
+	rule = strings.TrimPrefix(strings.ToUpper(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Recurrence{}, fmt.Errorf("malformed recurrence part %q", part)
+		}
+		switch key {
+		case "FREQ":
+			switch f := Frequency(value); f {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				r.Freq = f
+			default:
+				return Recurrence{}, fmt.Errorf("unsupported recurrence frequency %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("invalid recurrence interval %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Recurrence{}, fmt.Errorf("invalid recurrence count %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(value)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			r.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("invalid recurrence weekday %q", code)
+				}
+				r.ByDay = append(r.ByDay, day)
+			}
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported recurrence part %q", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return Recurrence{}, fmt.Errorf("recurrence rule requires FREQ")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return Recurrence{}, fmt.Errorf("recurrence rule cannot have both COUNT and UNTIL")
+	}
+	if len(r.ByDay) > 0 && r.Freq != FrequencyWeekly {
+		return Recurrence{}, fmt.Errorf("BYDAY is only supported for weekly recurrence")
+	}
+	sort.Slice(r.ByDay, func(i, j int) bool {
+		return mondayIndex(r.ByDay[i]) < mondayIndex(r.ByDay[j])
+	})
+	return r, nil
+}
+
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// A date-only UNTIL includes the whole day
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until %q", value)
+}
+
+// String renders the rule in canonical RRULE form
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			for code, d := range weekdayCodes {
+				if d == day {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after prev, treating prev as an occurrence
+// of the series. Wall-clock time is kept in prev's location. It reports false
+// when the rule has no further occurrences before UNTIL.
+func (r Recurrence) Next(prev time.Time) (time.Time, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var candidate time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		candidate = prev.AddDate(0, 0, interval)
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			candidate = prev.AddDate(0, 0, 7*interval)
+		} else {
+			candidate = r.nextByDay(prev, interval)
+		}
+	case FrequencyMonthly, FrequencyYearly:
+		next, ok := r.nextSameDay(prev, interval)
+		if !ok {
+			return time.Time{}, false
+		}
+		candidate = next
+	default:
+		return time.Time{}, false
+	}
+
+	if r.Until != nil && candidate.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return candidate, true
+}
+
+// nextByDay finds the next listed weekday after prev, jumping INTERVAL weeks
+// once the days of prev's week (weeks start on Monday) are used up
+func (r Recurrence) nextByDay(prev time.Time, interval int) time.Time {
+	weekStart := prev.AddDate(0, 0, -mondayIndex(prev.Weekday()))
+	for _, day := range r.ByDay {
+		if candidate := weekStart.AddDate(0, 0, mondayIndex(day)); candidate.After(prev) {
+			return candidate
+		}
+	}
+	return weekStart.AddDate(0, 0, 7*interval+mondayIndex(r.ByDay[0]))
+}
+
+// nextSameDay steps whole months (or years) from prev until it finds one that
+// has prev's day of the month; months without it (e.g. the 31st) are skipped
+// as in RFC 5545
+func (r Recurrence) nextSameDay(prev time.Time, interval int) (time.Time, bool) {
+	months := interval
+	if r.Freq == FrequencyYearly {
+		months *= 12
+	}
+
+	y, m, d := prev.Date()
+	for step := 1; step <= maxRecurrenceSteps; step++ {
+		first := time.Date(y, m+time.Month(step*months), 1, prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), prev.Location())
+		if candidate := first.AddDate(0, 0, d-1); candidate.Month() == first.Month() {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// mondayIndex numbers weekdays from Monday (0) to Sunday (6)
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule      string
+		canonical string
+		wantErr   bool
+	}{
+		{"weekly", "FREQ=WEEKLY", false},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=WE,MO", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", false},
+		{"FREQ=MONTHLY;COUNT=12", "FREQ=MONTHLY;COUNT=12", false},
+		{"FREQ=DAILY;UNTIL=20240531T000000Z", "FREQ=DAILY;UNTIL=20240531T000000Z", false},
+		{"FREQ=HOURLY", "", true},
+		{"FREQ=MONTHLY;BYDAY=MO", "", true},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20240531", "", true},
+		{"INTERVAL=2", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if err == nil && rule.String() != tt.canonical {
+				t.Errorf("expected canonical rule: %s, got: %s", tt.canonical, rule.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2024-01-31 is a Wednesday
+	prev := time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		expected time.Time
+		ok       bool
+	}{
+		{"daily", time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC), true},
+		{"FREQ=WEEKLY;INTERVAL=2", time.Date(2024, 2, 14, 9, 30, 0, 0, time.UTC), true},
+		{"FREQ=WEEKLY;BYDAY=MO,FR", time.Date(2024, 2, 2, 9, 30, 0, 0, time.UTC), true},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", time.Date(2024, 2, 12, 9, 30, 0, 0, time.UTC), true},
+		{"monthly", time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC), true}, // February has no 31st
+		{"yearly", time.Date(2025, 1, 31, 9, 30, 0, 0, time.UTC), true},
+		{"FREQ=DAILY;UNTIL=20240131", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			next, ok := rule.Next(prev)
+			if ok != tt.ok || !next.Equal(tt.expected) {
+				t.Errorf("expected: %v (%v), got: %v (%v)", tt.expected, tt.ok, next, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ErrReminderNotFound is returned when a reminder with the requested ID does not exist on the task
+var ErrReminderNotFound = fmt.Errorf("reminder %w", ErrNotFound)
+
+// ReminderStatus tracks whether a reminder still has to be delivered
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending" // Waiting for its fire time
+	ReminderSent    ReminderStatus = "sent"    // Delivered by the notifier
+	ReminderFailed  ReminderStatus = "failed"  // Given up on after repeated delivery errors
+	ReminderSkipped ReminderStatus = "skipped" // Not delivered because the task was already finished
+)
+
+// Reminder nudges whoever owns a task at a set time, given either as an
+// absolute time or as an offset before the task's due date.
+type Reminder struct {
+	ID            uint           `json:"id"`                                                   // Unique identifier
+	TaskID        uint           `json:"task_id" gorm:"not null;index"`                        // Task the reminder is about
+	RemindAt      *time.Time     `json:"remind_at,omitempty"`                                  // Absolute time to remind at
+	OffsetMinutes *int           `json:"offset_minutes,omitempty"`                             // Minutes before the due date to remind at
+	FireAt        *time.Time     `json:"fire_at,omitempty" gorm:"index"`                       // Next delivery time; nil while an offset reminder's task has no due date
+	Status        ReminderStatus `json:"status" gorm:"size:16;not null;default:pending;index"` // Delivery state
+	Attempts      int            `json:"attempts"`                                             // Failed delivery attempts so far
+	LastError     string         `json:"last_error,omitempty" gorm:"size:255"`                 // Error of the last failed delivery
+	SentAt        *time.Time     `json:"sent_at,omitempty"`                                    // When the reminder was delivered
+	CreatedAt     time.Time      `json:"created_at"`                                           // Timestamp of reminder creation
+}
+
+// Schedule computes when the reminder should fire for the given due date.
+// Offset reminders of tasks without a due date have no fire time.
+func (r Reminder) Schedule(due *time.Time) *time.Time {
+	if r.RemindAt != nil {
+		at := *r.RemindAt
+		return &at
+	}
+	if r.OffsetMinutes == nil || due == nil {
+		return nil
+	}
+	at := due.Add(-time.Duration(*r.OffsetMinutes) * time.Minute)
+	return &at
+}
+
+// Notification is what a Notifier delivers when a reminder fires
+type Notification struct {
+	Reminder  Reminder
+	Task      Task
+	Recipient *User // Assignee of the task, when it has one
+}
+
+// Notifier delivers fired reminders, e.g. to a log, a webhook or a mailbox
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// ReminderRepository is an interface for interacting with reminder storage
+type ReminderRepository interface {
+	Save(reminder Reminder) (uint, error)
+	FindByID(id uint) (Reminder, error)
+	FindByTask(taskID uint) ([]Reminder, error)
+	// FindDue returns pending reminders whose fire time is at or before the given time, earliest first
+	FindDue(before time.Time) ([]Reminder, error)
+	Update(reminder Reminder) (Reminder, error)
+	Delete(id uint) error
+	DeleteByTask(taskID uint) error
+}
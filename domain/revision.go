@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ErrRevisionNotFound is returned when a revision with the requested ID does not exist on the task
+var ErrRevisionNotFound = fmt.Errorf("revision %w", ErrNotFound)
+
+// RevisionAction names the kind of change a revision records
+type RevisionAction string
+
+const (
+	RevisionCreate   RevisionAction = "create"
+	RevisionUpdate   RevisionAction = "update"
+	RevisionStatus   RevisionAction = "status"
+	RevisionComplete RevisionAction = "complete"
+	RevisionDelete   RevisionAction = "delete"
+	RevisionRestore  RevisionAction = "restore"
+	RevisionRevert   RevisionAction = "revert"
+)
+
+// FieldChange is the value of one task field before and after a revision,
+// each encoded the way the field appears in the task's JSON
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// Revision is an entry in the change history of a task
+type Revision struct {
+	ID        uint           `json:"id"`                                       // Unique identifier
+	TaskID    uint           `json:"task_id" gorm:"not null;index"`            // Task the revision belongs to
+	Action    RevisionAction `json:"action" gorm:"size:32;not null"`           // Kind of change
+	ActorID   *uint          `json:"actor_id,omitempty" gorm:"index"`          // User who made the change, when known
+	Changes   []FieldChange  `json:"changes" gorm:"type:text;serializer:json"` // Fields the change touched
+	CreatedAt time.Time      `json:"created_at"`                               // When the change was made
+}
+
+// revisionFields lists the task fields revisions track, in the order their
+// changes are reported. Each entry points at the field so it can be both
+// encoded and, when undo is set, restored. Tags, checklist, custom field values,
+// position and deletion are only reported: their items may be gone by the time
+// a revision is undone, positions only change through Move, and tasks only
+// enter and leave the trash by being deleted and restored.
+var revisionFields = []struct {
+	name  string
+	field func(t *Task) any
+	undo  bool
+}{
+	{"title", func(t *Task) any { return &t.Title }, true},
+	{"description", func(t *Task) any { return &t.Description }, true},
+	{"status", func(t *Task) any { return &t.Status }, true},
+	{"completed", func(t *Task) any { return &t.Completed }, true},
+	{"priority", func(t *Task) any { return &t.Priority }, true},
+	{"due_date", func(t *Task) any { return &t.DueDate }, true},
+	{"due_has_time", func(t *Task) any { return &t.DueHasTime }, true},
+	{"due_timezone", func(t *Task) any { return &t.DueTimezone }, true},
+	{"estimate_minutes", func(t *Task) any { return &t.Estimate }, true},
+	{"assignee_id", func(t *Task) any { return &t.AssigneeID }, true},
+	{"project_id", func(t *Task) any { return &t.ProjectID }, true},
+	{"parent_id", func(t *Task) any { return &t.ParentID }, true},
+	{"recurrence", func(t *Task) any { return &t.Recurrence }, true},
+	{"archived_at", func(t *Task) any { return &t.ArchivedAt }, true},
+	{"tags", func(t *Task) any { return &t.Tags }, false},
+	{"checklist", func(t *Task) any { return &t.Checklist }, false},
+	{"custom_fields", func(t *Task) any { return &t.FieldValues }, false},
+	{"position", func(t *Task) any { return &t.Position }, false},
+	{"deleted_at", func(t *Task) any { return &t.DeletedAt }, false},
+}
+
+// DiffTasks returns the tracked fields whose values differ between two versions of a task
+func DiffTasks(before, after Task) ([]FieldChange, error) {
+	changes := make([]FieldChange, 0)
+	for _, f := range revisionFields {
+		old, err := json.Marshal(f.field(&before))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := json.Marshal(f.field(&after))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(old, updated) {
+			changes = append(changes, FieldChange{Field: f.name, Before: old, After: updated})
+		}
+	}
+	return changes, nil
+}
+
+// Undo sets every field the changes touched back to its value before them.
+// Changes to fields that are only reported, or that revisions no longer
+// track, are ignored.
+func (t *Task) Undo(changes []FieldChange) error {
+	for _, change := range changes {
+		for _, f := range revisionFields {
+			if f.name != change.Field || !f.undo {
+				continue
+			}
+			if err := json.Unmarshal(change.Before, f.field(t)); err != nil {
+				return fmt.Errorf("revision field %s: %w", change.Field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RevisionRepository is an interface for interacting with revision storage
+type RevisionRepository interface {
+	Save(revision Revision) (uint, error)
+	// FindByTask returns a task's revisions, oldest first
+	FindByTask(taskID uint) ([]Revision, error)
+	DeleteByTask(taskID uint) error
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffTasks_UndoRoundTrip(t *testing.T) {
+	due := time.Date(2024, 5, 3, 17, 30, 0, 0, time.UTC)
+	before := Task{ID: 1, Title: "Draft", Description: "first pass", Priority: PriorityLow, Status: StatusTodo}
+	after := before
+	after.Title = "Ship"
+	after.Priority = PriorityUrgent
+	after.DueDate = &due
+	after.DueHasTime = true
+
+	changes, err := DiffTasks(before, after)
+	if err != nil {
+		t.Fatalf("DiffTasks: %v", err)
+	}
+	var fields []string
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	want := []string{"title", "priority", "due_date", "due_has_time"}
+	if len(fields) != len(want) {
+		t.Fatalf("changed fields = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Fatalf("changed fields = %v, want %v", fields, want)
+		}
+	}
+
+	undone := after
+	if err := undone.Undo(changes); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if undone.Title != before.Title || undone.Priority != before.Priority || undone.DueDate != nil || undone.DueHasTime {
+		t.Errorf("Undo = %+v, want the fields of %+v", undone, before)
+	}
+	if undone.Description != before.Description {
+		t.Errorf("Undo touched an unchanged field: description = %q", undone.Description)
+	}
+}
+
+func TestDiffTasks_NoChanges(t *testing.T) {
+	task := Task{ID: 1, Title: "Same"}
+	changes, err := DiffTasks(task, task)
+	if err != nil {
+		t.Fatalf("DiffTasks: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("DiffTasks = %v, want no changes", changes)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchHit is a task found by a full-text search
+type SearchHit struct {
+	Task       Task             `json:"task"`
+	Score      float64          `json:"score"`      // Relevance of the task; higher is better
+	Highlights SearchHighlights `json:"highlights"` // Where the task matched
+}
+
+// SearchHighlights shows the matched words of a task, each wrapped in <mark>
+// tags, with the rest of the text HTML-escaped
+type SearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"` // A snippet of the description around its first match
+}
+
+// Tokenize splits text into the lowercase words search indexes and matches.
+// A word is a run of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchTerms returns the distinct words of a search query. Each term
+// matches any word it is a prefix of.
+func SearchTerms(query string) []string {
+	seen := make(map[string]bool)
+	terms := make([]string, 0)
+	for _, word := range Tokenize(query) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// MatchesTerm reports whether a word matches one of the search terms
+func MatchesTerm(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
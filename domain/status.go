@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the stage of its workflow a task is in
+type Status string
+
+const (
+	StatusTodo       Status = "todo"
+	StatusInProgress Status = "in_progress"
+	StatusBlocked    Status = "blocked"
+	StatusInReview   Status = "in_review"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+)
+
+var statuses = []Status{StatusTodo, StatusInProgress, StatusBlocked, StatusInReview, StatusDone, StatusCancelled}
+
+// ParseStatus converts a status name into a Status
+func ParseStatus(name string) (Status, error) {
+	for _, s := range statuses {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %q", name)
+}
+
+// Valid reports whether the status is one of the known stages
+func (s Status) Valid() bool {
+	_, err := ParseStatus(string(s))
+	return err == nil
+}
+
+// Terminal reports whether a task in this status is finished with
+func (s Status) Terminal() bool {
+	return s == StatusDone || s == StatusCancelled
+}
+
+// Workflow lists, for every status, the statuses a task may move to next
+type Workflow map[Status][]Status
+
+// DefaultWorkflow moves tasks from todo through in_progress and in_review to
+// done. Any open task can become blocked or be cancelled, and finished tasks
+// can be reopened.
+var DefaultWorkflow = Workflow{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusInReview, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+	StatusInReview:   {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+	StatusDone:       {StatusTodo},
+	StatusCancelled:  {StatusTodo},
+}
+
+// Allows reports whether a task may move from one status to another.
+// Staying in the same status is always allowed.
+func (w Workflow) Allows(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range w[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentStatus returns the task's status, deriving it from Completed for
+// tasks stored before statuses existed
+func (t Task) CurrentStatus() Status {
+	if t.Status != "" {
+		return t.Status
+	}
+	if t.Completed {
+		return StatusDone
+	}
+	return StatusTodo
+}
+
+// SetStatus moves the task to the given status without consulting a workflow,
+// keeping Completed and CompletedAt consistent with it. Reopening an archived
+// task takes it out of the archive.
+func (t *Task) SetStatus(status Status, now time.Time) {
+	wasTerminal := t.CurrentStatus().Terminal()
+	t.Status = status
+	t.Completed = status.Terminal()
+	switch {
+	case !status.Terminal():
+		t.CompletedAt = nil
+		t.ArchivedAt = nil
+	case !wasTerminal || t.CompletedAt == nil:
+		t.CompletedAt = &now
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrTagNotFound is returned when a tag with the requested ID or name does not exist
+var ErrTagNotFound = fmt.Errorf("tag %w", ErrNotFound)
+
+// Tag represents a label used to group tasks by area
+type Tag struct {
+	ID        uint      `json:"id"`                              // Unique identifier
+	Name      string    `json:"name" gorm:"size:64;uniqueIndex"` // Unique, lower-case tag name
+	CreatedAt time.Time `json:"created_at"`                      // Timestamp of tag creation
+}
+
+// TagRepository is an interface for interacting with tag storage
+type TagRepository interface {
+	Save(tag Tag) (uint, error)
+	FindByID(id uint) (Tag, error)
+	FindByName(name string) (Tag, error)
+	FindAll() ([]Tag, error)
+	Update(tag Tag) (Tag, error)
+	Delete(id uint) error
+}
+
+// HasTag reports whether the task carries a tag with the given ID
+func (t Task) HasTag(tagID uint) bool {
+	for _, tag := range t.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
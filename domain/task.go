@@ -1,14 +1,55 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is the base error returned by repositories when a record does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrDuplicate is the base error returned by repositories when a record
+// clashes with a unique one already stored
+var ErrDuplicate = errors.New("already exists")
+
+// ErrTaskNotFound is returned when a task with the requested ID does not exist
+var ErrTaskNotFound = fmt.Errorf("task %w", ErrNotFound)
 
 // Task represents a domain entity for a to-do item
 type Task struct {
-	ID          uint      `json:"id"`          // Unique identifier
-	Title       string    `json:"title"`       // Title of the task
-	Description string    `json:"description"` // Detailed description of the task
-	Completed   bool      `json:"completed"`   // Task completion status
-	CreatedAt   time.Time `json:"created_at"`  // Timestamp of task creation
+	ID          uint            `json:"id"`                                                // Unique identifier
+	Title       string          `json:"title"`                                             // Title of the task
+	Description string          `json:"description"`                                       // Detailed description of the task
+	Completed   bool            `json:"completed"`                                         // Whether the task's status is terminal; kept in sync with Status
+	CreatedAt   time.Time       `json:"created_at" gorm:"index"`                           // Timestamp of task creation; indexed for paging by creation time
+	DueDate     *time.Time      `json:"due_date,omitempty" gorm:"index"`                   // Optional due date
+	DueHasTime  bool            `json:"due_has_time"`                                      // Whether the due date carries a time-of-day
+	DueTimezone string          `json:"due_timezone,omitempty" gorm:"size:64"`             // IANA timezone the due date is expressed in
+	Priority    Priority        `json:"priority" gorm:"not null;default:0;index"`          // Triage priority of the task
+	Tags        []Tag           `json:"tags,omitempty" gorm:"many2many:task_tags"`         // Labels attached to the task
+	ParentID    *uint           `json:"parent_id,omitempty" gorm:"index"`                  // Parent task when this task is a subtask
+	Recurrence  string          `json:"recurrence,omitempty" gorm:"size:255"`              // RRULE describing how the task repeats
+	SeriesID    *uint           `json:"series_id,omitempty" gorm:"index"`                  // First task of the recurring series this task was spawned from
+	ProjectID   *uint           `json:"project_id,omitempty" gorm:"index"`                 // Project the task belongs to
+	Status      Status          `json:"status" gorm:"size:32;not null;default:todo;index"` // Workflow stage of the task
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`                            // When the task last reached a terminal status
+	AssigneeID  *uint           `json:"assignee_id,omitempty" gorm:"index"`                // User responsible for the task
+	CreatorID   *uint           `json:"creator_id,omitempty" gorm:"index"`                 // User who created the task
+	Estimate    int             `json:"estimate_minutes,omitempty"`                        // Expected effort in minutes
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty" gorm:"index"`                 // When the task was moved to the trash
+	ArchivedAt  *time.Time      `json:"archived_at,omitempty" gorm:"index"`                // When the completed task was archived
+	Position    int64           `json:"position" gorm:"not null;default:0;index"`          // Manual order of the task; see PositionGap
+	Checklist   []ChecklistItem `json:"checklist,omitempty" gorm:"foreignKey:TaskID"`      // Ordered inline checklist
+	FieldValues []FieldValue    `json:"custom_fields,omitempty" gorm:"foreignKey:TaskID"`  // Values of the project's custom fields
+}
+
+// SeriesRoot returns the ID of the first task in the task's recurring series
+func (t Task) SeriesRoot() uint {
+	if t.SeriesID != nil {
+		return *t.SeriesID
+	}
+	return t.ID
 }
 
 // TaskRepository is an interface for interacting with task storage
@@ -16,6 +57,42 @@ type TaskRepository interface {
 	Save(task Task) (uint, error)
 	FindByID(id uint) (Task, error)
 	FindAll() ([]Task, error)
+	// Query returns one page of the tasks matching the query, in its order
+	Query(query TaskQuery) (TaskPage, error)
+	// Search returns up to limit tasks, archived ones included, with a word in
+	// their title or description matching each of the terms (see SearchTerms),
+	// most relevant first
+	Search(terms []string, limit int) ([]SearchHit, error)
+	FindByTags(names []string, matchAll bool) ([]Task, error)
+	FindOpenDueBefore(before time.Time) ([]Task, error)
+	FindChildren(parentID uint) ([]Task, error)
+	FindSeries(seriesID uint) ([]Task, error)
+	FindByProject(projectID uint) ([]Task, error)
+	CountByProject() (map[uint]TaskCounts, error)
+	FindBlockers(taskID uint) ([]Task, error)
+	FindReady() ([]Task, error)
+	FindByAssignee(userID uint) ([]Task, error)
+	FindUnassigned() ([]Task, error)
+	// FindByFieldValues returns the tasks holding every one of the given custom field values
+	FindByFieldValues(values []FieldValue) ([]Task, error)
+	AddDependency(dependency TaskDependency) error
+	RemoveDependency(taskID, blockerID uint) error
+	// Update leaves the task's position alone; positions only change through
+	// Move. It also updates tasks in the trash, which stay there.
 	Update(task Task) (Task, error)
+	// Move places a task directly before (or, with after, directly after) the
+	// anchor task, respacing the list when there is no room left between them
+	Move(id, anchorID uint, after bool) (Task, error)
+	// Archived tasks are left out of FindAll, Query, FindByTags,
+	// FindByProject, FindByAssignee and FindUnassigned
+	FindArchived(query string) ([]Task, error)
+	// ArchiveCompletedBefore returns the tasks it archived, as they are now
+	ArchiveCompletedBefore(before, at time.Time) ([]Task, error)
+	// Trash hides a task from every other query until it is restored
+	Trash(id uint, at time.Time) error
+	Restore(id uint) error
+	FindTrashed() ([]Task, error)
+	FindTrashedByID(id uint) (Task, error)
+	// Delete permanently removes a task, trashed or not
 	Delete(id uint) error
 }
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a page cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TaskSort names the field a task query is ordered by
+type TaskSort string
+
+const (
+	SortByCreated  TaskSort = "created_at"
+	SortByTitle    TaskSort = "title"
+	SortByPriority TaskSort = "priority"
+	SortByPosition TaskSort = "position"
+)
+
+// Valid reports whether s is one of the known sort fields
+func (s TaskSort) Valid() bool {
+	switch s {
+	case SortByCreated, SortByTitle, SortByPriority, SortByPosition:
+		return true
+	}
+	return false
+}
+
+// TaskQuery selects one page of the tasks shown in default listings, that is
+// those neither in the trash nor archived. Tasks with the same sort value are
+// ordered by ID, in the same direction; tasks of the same priority are first
+// ordered by due date, the opposite way, so with the most important first
+// come the earliest due and then those without a due date.
+type TaskQuery struct {
+	Completed     *bool       // Only completed, or only open, tasks
+	CreatedAfter  *time.Time  // Only tasks created at or after this time
+	CreatedBefore *time.Time  // Only tasks created before this time
+	Text          string      // Only tasks whose title or description contains this text, ignoring case
+	Sort          TaskSort    // Field to order by
+	Desc          bool        // Whether to order from the largest value down
+	Limit         int         // Maximum number of tasks in the page; must be positive
+	After         *TaskCursor // Start right after the task this cursor was taken at
+}
+
+// TaskPage is one page of the results of a TaskQuery
+type TaskPage struct {
+	Tasks []Task
+	Next  *TaskCursor // Where the next page starts; nil on the last page
+}
+
+// Matches reports whether the task passes the query's filters
+func (q TaskQuery) Matches(task Task) bool {
+	if q.Completed != nil && task.Completed != *q.Completed {
+		return false
+	}
+	if q.CreatedAfter != nil && task.CreatedAt.Before(*q.CreatedAfter) {
+		return false
+	}
+	if q.CreatedBefore != nil && !task.CreatedAt.Before(*q.CreatedBefore) {
+		return false
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(task.Title), text) && !strings.Contains(strings.ToLower(task.Description), text) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare orders two tasks the way the query sorts them
+func (q TaskQuery) Compare(a, b Task) int {
+	var c int
+	switch q.Sort {
+	case SortByTitle:
+		c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case SortByPriority:
+		c = cmp.Compare(a.Priority, b.Priority)
+		if c == 0 {
+			c = -compareDueDates(a.DueDate, b.DueDate)
+		}
+	case SortByPosition:
+		c = cmp.Compare(a.Position, b.Position)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.ID, b.ID)
+	}
+	if q.Desc {
+		return -c
+	}
+	return c
+}
+
+// compareDueDates orders due dates earliest first, with no due date last
+func compareDueDates(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+// TaskCursor marks a task by every value tasks can be sorted on, so a page
+// can start right after it whichever way the query is sorted
+type TaskCursor struct {
+	ID        uint       `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Title     string     `json:"title"`
+	Priority  Priority   `json:"priority"`
+	Position  int64      `json:"position"`
+	DueDate   *time.Time `json:"due_date,omitempty"` // Breaks ties between tasks of the same priority
+}
+
+// CursorAt returns the cursor of the given task
+func CursorAt(task Task) TaskCursor {
+	return TaskCursor{ID: task.ID, CreatedAt: task.CreatedAt, Title: task.Title, Priority: task.Priority, Position: task.Position, DueDate: task.DueDate}
+}
+
+// Task returns a task carrying the cursor's sort values, for comparing with TaskQuery.Compare
+func (c TaskCursor) Task() Task {
+	return Task{ID: c.ID, CreatedAt: c.CreatedAt, Title: c.Title, Priority: c.Priority, Position: c.Position, DueDate: c.DueDate}
+}
+
+// Key returns the cursor's value for the given sort field. Titles sort
+// ignoring case, so the title key is lower-cased.
+func (c TaskCursor) Key(sort TaskSort) any {
+	switch sort {
+	case SortByTitle:
+		return strings.ToLower(c.Title)
+	case SortByPriority:
+		return int(c.Priority)
+	case SortByPosition:
+		return c.Position
+	default:
+		return c.CreatedAt
+	}
+}
+
+// Encode returns the cursor as an opaque, URL-safe string
+func (c TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseTaskCursor decodes a cursor produced by TaskCursor.Encode
+func ParseTaskCursor(s string) (TaskCursor, error) {
+	var cursor TaskCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return TaskCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskCursor_RoundTrip(t *testing.T) {
+	task := Task{ID: 7, Title: "Write report", Priority: PriorityHigh, Position: 3 * PositionGap, CreatedAt: time.Date(2024, 5, 2, 9, 30, 0, 123, time.UTC)}
+	cursor := CursorAt(task)
+
+	parsed, err := ParseTaskCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("ParseTaskCursor: %v", err)
+	}
+	if parsed.ID != cursor.ID || parsed.Title != cursor.Title || parsed.Priority != cursor.Priority ||
+		parsed.Position != cursor.Position || !parsed.CreatedAt.Equal(cursor.CreatedAt) {
+		t.Errorf("ParseTaskCursor = %+v, want %+v", parsed, cursor)
+	}
+
+	for _, bad := range []string{"", "not base64!", "e30"} {
+		if _, err := ParseTaskCursor(bad); err != ErrInvalidCursor {
+			t.Errorf("ParseTaskCursor(%q) error = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
+
+func TestTaskQuery_CompareBreaksTiesByID(t *testing.T) {
+	a := Task{ID: 1, Priority: PriorityHigh}
+	b := Task{ID: 2, Priority: PriorityHigh}
+	c := Task{ID: 3, Priority: PriorityLow}
+
+	query := TaskQuery{Sort: SortByPriority, Desc: true}
+	if query.Compare(a, b) <= 0 {
+		t.Errorf("descending: task 2 should come before task 1 at the same priority")
+	}
+	if query.Compare(a, c) >= 0 {
+		t.Errorf("descending: high priority should come before low")
+	}
+}
+
+func TestTaskQuery_CompareOrdersPriorityTiesByDueDate(t *testing.T) {
+	soon := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 7)
+	a := Task{ID: 1, Priority: PriorityHigh, DueDate: &later}
+	b := Task{ID: 2, Priority: PriorityHigh, DueDate: &soon}
+	c := Task{ID: 3, Priority: PriorityHigh}
+
+	query := TaskQuery{Sort: SortByPriority, Desc: true}
+	if query.Compare(b, a) >= 0 || query.Compare(a, c) >= 0 {
+		t.Errorf("descending: expected the earliest due date first and no due date last")
+	}
+	query.Desc = false
+	if query.Compare(c, a) >= 0 || query.Compare(a, b) >= 0 {
+		t.Errorf("ascending: expected the opposite order")
+	}
+
+	// The cursor carries the due date, so a page can start between them
+	cursor, err := ParseTaskCursor(CursorAt(b).Encode())
+	if err != nil || cursor.DueDate == nil || !cursor.DueDate.Equal(soon) {
+		t.Fatalf("expected the cursor to keep the due date, got %+v, %v", cursor, err)
+	}
+	if query.Compare(a, cursor.Task()) >= 0 {
+		t.Errorf("ascending: expected task 1 before the cursor at task 2")
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeEntryNotFound is returned when a time entry with the requested ID does not exist on the task
+var ErrTimeEntryNotFound = fmt.Errorf("time entry %w", ErrNotFound)
+
+// ErrTimerRunning is returned when starting a timer for a user who already has one running
+var ErrTimerRunning = errors.New("timer already running")
+
+// TimeEntry is a span of time spent working on a task. Entries without an end
+// are running timers.
+type TimeEntry struct {
+	ID        uint       `json:"id"`                                   // Unique identifier
+	TaskID    uint       `json:"task_id" gorm:"not null;index"`        // Task the time was spent on
+	UserID    *uint      `json:"user_id,omitempty" gorm:"index"`       // User who spent the time
+	StartedAt time.Time  `json:"started_at" gorm:"not null"`           // When work started
+	EndedAt   *time.Time `json:"ended_at,omitempty" gorm:"index"`      // When work stopped; nil while the timer runs
+	Note      string     `json:"note,omitempty" gorm:"size:255"`       // What the time was spent on
+	Manual    bool       `json:"manual" gorm:"not null;default:false"` // Whether the entry was logged by hand rather than timed
+}
+
+// Running reports whether the entry is a timer that has not been stopped
+func (e TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// Duration returns the time covered by the entry; running timers count up to now
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// TimeTotals compares the estimated effort of one or more tasks with the time tracked on them
+type TimeTotals struct {
+	EstimateMinutes int   `json:"estimate_minutes"` // Sum of the tasks' estimates
+	TrackedSeconds  int64 `json:"tracked_seconds"`  // Time tracked so far, including running timers
+	Entries         int   `json:"entries"`          // Number of time entries
+	Running         int   `json:"running"`          // Number of timers still running
+}
+
+// TimeEntryRepository is an interface for interacting with time entry storage
+type TimeEntryRepository interface {
+	Save(entry TimeEntry) (uint, error)
+	// Start saves a running timer, failing with ErrTimerRunning when the
+	// entry's user already has one; the check and insert are atomic
+	Start(entry TimeEntry) (uint, error)
+	FindByID(id uint) (TimeEntry, error)
+	FindByTask(taskID uint) ([]TimeEntry, error)
+	FindByProject(projectID uint) ([]TimeEntry, error)
+	FindRunning(userID uint) (TimeEntry, error)
+	Update(entry TimeEntry) (TimeEntry, error)
+	Delete(id uint) error
+	DeleteByTask(taskID uint) error
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrUserNotFound is returned when a user with the requested ID does not exist
+var ErrUserNotFound = fmt.Errorf("user %w", ErrNotFound)
+
+// User represents a member of the team sharing the to-do server
+type User struct {
+	ID        uint      `json:"id"`                                // Unique identifier
+	Name      string    `json:"name" gorm:"size:128"`              // Display name of the user
+	Email     string    `json:"email" gorm:"size:255;uniqueIndex"` // Email address, unique per user
+	CreatedAt time.Time `json:"created_at"`                        // Timestamp of user creation
+}
+
+// UserRepository is an interface for interacting with user storage
+type UserRepository interface {
+	Save(user User) (uint, error)
+	FindByID(id uint) (User, error)
+	FindByEmail(email string) (User, error)
+	FindAll() ([]User, error)
+	Delete(id uint) error
+}
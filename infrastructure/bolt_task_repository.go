@@ -0,0 +1,990 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the bolt task store. Every index bucket holds keys only, each
+// ending in the ID of the task it points at, and is written in the same
+// transaction as the task so the two never disagree.
+var (
+	boltTasks       = []byte("tasks")              // task ID to the task as encodeTask writes it; its sequence numbers tasks
+	boltByCompleted = []byte("tasks_by_completed") // completed flag, then task ID
+	boltByCreated   = []byte("tasks_by_created")   // creation time, then task ID
+	boltByPosition  = []byte("tasks_by_position")  // manual position, then task ID
+	boltBlockers    = []byte("task_blockers")      // task ID, then the ID of a task blocking it
+	boltChecklist   = []byte("checklist_items")    // empty; its sequence numbers checklist items
+)
+
+// BoltTaskRepository stores tasks in an embedded bbolt key-value file, so no
+// database server is needed. Each write is a single bbolt transaction, which
+// is fsynced before it returns and never leaves the file half-written, so the
+// store survives a crash at any point with every committed write intact.
+//
+// Tags and custom fields are kept elsewhere. Tasks store the tags and field
+// values they were saved with, and reads drop or refresh those that were
+// deleted or renamed since, the way a join would.
+type BoltTaskRepository struct {
+	db     *bolt.DB
+	tags   domain.TagRepository         // Current tags; nil leaves stored tags as they are
+	fields domain.CustomFieldRepository // Current custom fields; nil leaves stored values as they are
+	mutex  sync.Mutex                   // Serializes writes with the search index updates following them
+	index  *searchIndex                 // Words of the tasks outside the trash, for Search
+}
+
+// NewBoltTaskRepository opens, or creates, the bolt file at path. It fails
+// when another process holds the file open.
+func NewBoltTaskRepository(path string, tags domain.TagRepository, fields domain.CustomFieldRepository) (*BoltTaskRepository, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	r := &BoltTaskRepository{db: db, tags: tags, fields: fields, index: newSearchIndex()}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltTasks, boltByCompleted, boltByCreated, boltByPosition, boltBlockers, boltChecklist} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return eachTask(tx, func(task domain.Task) error {
+			if task.DeletedAt == nil {
+				r.index.add(task)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Close releases the bolt file
+func (r *BoltTaskRepository) Close() error {
+	return r.db.Close()
+}
+
+// idKey encodes an ID so keys sort in ID order
+func idKey(id uint) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+// keyID reads the task ID every key ends in
+func keyID(key []byte) uint {
+	return uint(binary.BigEndian.Uint64(key[len(key)-8:]))
+}
+
+// sortableInt encodes a signed number so its bytes sort in numeric order
+func sortableInt(key []byte, n int64) []byte {
+	return binary.BigEndian.AppendUint64(key, uint64(n)^1<<63)
+}
+
+// completedPrefix starts the keys of every task with the given completion status
+func completedPrefix(completed bool) []byte {
+	if completed {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+func completedKey(completed bool, id uint) []byte {
+	return append(completedPrefix(completed), idKey(id)...)
+}
+
+func createdKey(at time.Time, id uint) []byte {
+	key := sortableInt(nil, at.Unix())
+	key = binary.BigEndian.AppendUint32(key, uint32(at.Nanosecond()))
+	return append(key, idKey(id)...)
+}
+
+// keyCreated reads the creation time of a createdKey
+func keyCreated(key []byte) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint64(key)^1<<63), int64(binary.BigEndian.Uint32(key[8:])))
+}
+
+func positionKey(position int64, id uint) []byte {
+	return append(sortableInt(nil, position), idKey(id)...)
+}
+
+// keyPosition reads the position of a positionKey
+func keyPosition(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key) ^ 1<<63)
+}
+
+// getTask reads a stored task, trashed or not
+func getTask(tx *bolt.Tx, id uint) (domain.Task, bool, error) {
+	data := tx.Bucket(boltTasks).Get(idKey(id))
+	if data == nil {
+		return domain.Task{}, false, nil
+	}
+	task, err := decodeTask(data)
+	if err != nil {
+		return domain.Task{}, false, err
+	}
+	return task, true, nil
+}
+
+// eachTask calls fn with every stored task, trashed ones included, in ID order
+func eachTask(tx *bolt.Tx, fn func(task domain.Task) error) error {
+	return tx.Bucket(boltTasks).ForEach(func(k, _ []byte) error {
+		task, _, err := getTask(tx, keyID(k))
+		if err != nil {
+			return err
+		}
+		return fn(task)
+	})
+}
+
+// putTask stores the task and moves its index entries from where the old
+// version of it had them; old is nil for a new task
+func putTask(tx *bolt.Tx, old *domain.Task, task domain.Task) error {
+	if old != nil {
+		if err := deleteIndexes(tx, *old); err != nil {
+			return err
+		}
+	}
+	data, err := encodeTask(task)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltTasks).Put(idKey(task.ID), data); err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltByCompleted).Put(completedKey(task.Completed, task.ID), nil); err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltByCreated).Put(createdKey(task.CreatedAt, task.ID), nil); err != nil {
+		return err
+	}
+	return tx.Bucket(boltByPosition).Put(positionKey(task.Position, task.ID), nil)
+}
+
+// deleteIndexes removes the task's index entries
+func deleteIndexes(tx *bolt.Tx, task domain.Task) error {
+	if err := tx.Bucket(boltByCompleted).Delete(completedKey(task.Completed, task.ID)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltByCreated).Delete(createdKey(task.CreatedAt, task.ID)); err != nil {
+		return err
+	}
+	return tx.Bucket(boltByPosition).Delete(positionKey(task.Position, task.ID))
+}
+
+// numberItems links the task's checklist items and field values to it and numbers new checklist items
+func numberItems(tx *bolt.Tx, task *domain.Task) error {
+	for i := range task.FieldValues {
+		task.FieldValues[i].TaskID = task.ID
+	}
+	for i := range task.Checklist {
+		task.Checklist[i].TaskID = task.ID
+		if task.Checklist[i].ID == 0 {
+			id, err := tx.Bucket(boltChecklist).NextSequence()
+			if err != nil {
+				return err
+			}
+			task.Checklist[i].ID = uint(id)
+		}
+	}
+	return nil
+}
+
+// searchChanges queues the search index changes of a write until it commits
+type searchChanges []func(index *searchIndex)
+
+// add queues indexing the task, or dropping it from the index when it is in the trash
+func (c *searchChanges) add(task domain.Task) {
+	if !live(task) {
+		c.remove(task.ID)
+		return
+	}
+	*c = append(*c, func(index *searchIndex) { index.add(task) })
+}
+
+func (c *searchChanges) remove(id uint) {
+	*c = append(*c, func(index *searchIndex) { index.remove(id) })
+}
+
+// write runs fn in a read-write transaction, then applies the search index
+// changes fn queued once the transaction has committed
+func (r *BoltTaskRepository) write(fn func(tx *bolt.Tx, changes *searchChanges) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var changes searchChanges
+	if err := r.db.Update(func(tx *bolt.Tx) error { return fn(tx, &changes) }); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		change(r.index)
+	}
+	return nil
+}
+
+// taskResolver refreshes the tags and custom field values of stored tasks,
+// looking up each tag and custom field the tasks of one read refer to once
+type taskResolver struct {
+	tags    domain.TagRepository
+	fields  domain.CustomFieldRepository
+	current map[uint]*domain.Tag // Tags already looked up; nil for deleted ones
+	exists  map[uint]bool        // Custom field IDs already looked up
+}
+
+// resolver returns the resolver for the tasks of one read
+func (r *BoltTaskRepository) resolver() *taskResolver {
+	return &taskResolver{tags: r.tags, fields: r.fields, current: make(map[uint]*domain.Tag), exists: make(map[uint]bool)}
+}
+
+// resolve replaces the task's tags with their current versions and drops the
+// tags and field values whose tag or field no longer exists
+func (x *taskResolver) resolve(task *domain.Task) error {
+	if x.tags != nil && len(task.Tags) > 0 {
+		tags := make([]domain.Tag, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			current, known := x.current[tag.ID]
+			if !known {
+				found, err := x.tags.FindByID(tag.ID)
+				if err != nil && !errors.Is(err, domain.ErrNotFound) {
+					return err
+				}
+				if err == nil {
+					current = &found
+				}
+				x.current[tag.ID] = current
+			}
+			if current != nil {
+				tags = append(tags, *current)
+			}
+		}
+		task.Tags = tags
+	}
+	if x.fields != nil && len(task.FieldValues) > 0 {
+		values := make([]domain.FieldValue, 0, len(task.FieldValues))
+		for _, value := range task.FieldValues {
+			exists, known := x.exists[value.FieldID]
+			if !known {
+				_, err := x.fields.FindByID(value.FieldID)
+				if err != nil && !errors.Is(err, domain.ErrNotFound) {
+					return err
+				}
+				exists = err == nil
+				x.exists[value.FieldID] = exists
+			}
+			if exists {
+				values = append(values, value)
+			}
+		}
+		task.FieldValues = values
+	}
+	return nil
+}
+
+// find returns the resolved tasks keep accepts, in ID order
+func (r *BoltTaskRepository) find(keep func(task domain.Task) bool) ([]domain.Task, error) {
+	x := r.resolver()
+	tasks := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return eachTask(tx, func(task domain.Task) error {
+			if err := x.resolve(&task); err != nil {
+				return err
+			}
+			if keep(task) {
+				tasks = append(tasks, task)
+			}
+			return nil
+		})
+	})
+	return tasks, err
+}
+
+// findIndexed is find over the tasks an index bucket lists under prefix, in index order
+func (r *BoltTaskRepository) findIndexed(bucket, prefix []byte, keep func(task domain.Task) bool) ([]domain.Task, error) {
+	x := r.resolver()
+	tasks := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			task, _, err := getTask(tx, keyID(k))
+			if err != nil {
+				return err
+			}
+			if err := x.resolve(&task); err != nil {
+				return err
+			}
+			if keep(task) {
+				tasks = append(tasks, task)
+			}
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+// findIDs returns the resolved tasks with the given IDs that keep accepts, in the order of the IDs
+func (r *BoltTaskRepository) findIDs(ids []uint, keep func(task domain.Task) bool) ([]domain.Task, error) {
+	x := r.resolver()
+	tasks := make([]domain.Task, 0, len(ids))
+	err := r.db.View(func(tx *bolt.Tx) error {
+		for _, id := range ids {
+			task, exists, err := getTask(tx, id)
+			if err != nil {
+				return err
+			}
+			if !exists || !keep(task) {
+				continue
+			}
+			if err := x.resolve(&task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+// live reports whether the task is outside the trash
+func live(task domain.Task) bool {
+	return task.DeletedAt == nil
+}
+
+// listed reports whether the task is shown in default listings, that is neither trashed nor archived
+func listed(task domain.Task) bool {
+	return task.DeletedAt == nil && task.ArchivedAt == nil
+}
+
+func (r *BoltTaskRepository) Save(task domain.Task) (uint, error) {
+	err := r.write(func(tx *bolt.Tx, changes *searchChanges) error {
+		tasks := tx.Bucket(boltTasks)
+		if task.ID == 0 {
+			id, err := tasks.NextSequence()
+			if err != nil {
+				return err
+			}
+			task.ID = uint(id)
+		} else if uint64(task.ID) > tasks.Sequence() {
+			// Keep later allocated IDs clear of one chosen by the caller
+			if err := tasks.SetSequence(uint64(task.ID)); err != nil {
+				return err
+			}
+		}
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = time.Now()
+		}
+		if task.Position == 0 {
+			last := int64(0)
+			if k, _ := tx.Bucket(boltByPosition).Cursor().Last(); k != nil {
+				last = keyPosition(k)
+			}
+			task.Position = last + domain.PositionGap
+		}
+		if err := numberItems(tx, &task); err != nil {
+			return err
+		}
+
+		old, exists, err := getTask(tx, task.ID)
+		if err != nil {
+			return err
+		}
+		var previous *domain.Task
+		if exists {
+			previous = &old
+		}
+		if err := putTask(tx, previous, task); err != nil {
+			return err
+		}
+		changes.add(task)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return task.ID, nil
+}
+
+func (r *BoltTaskRepository) FindByID(id uint) (domain.Task, error) {
+	return r.findOne(id, live)
+}
+
+// findOne returns the resolved task with the given ID when it passes keep
+func (r *BoltTaskRepository) findOne(id uint, keep func(task domain.Task) bool) (domain.Task, error) {
+	x := r.resolver()
+	var task domain.Task
+	err := r.db.View(func(tx *bolt.Tx) error {
+		found, exists, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists || !keep(found) {
+			return domain.ErrTaskNotFound
+		}
+		task = found
+		return x.resolve(&task)
+	})
+	return task, err
+}
+
+func (r *BoltTaskRepository) FindAll() ([]domain.Task, error) {
+	return r.find(listed)
+}
+
+// Query returns one page of the listed tasks. Pages by creation time walk the
+// creation time index from the cursor, reading only as far as the page
+// needs; completion filters on other orders read only the tasks the
+// completion index lists under the wanted status.
+func (r *BoltTaskRepository) Query(query domain.TaskQuery) (domain.TaskPage, error) {
+	keep := func(task domain.Task) bool {
+		if !listed(task) || !query.Matches(task) {
+			return false
+		}
+		return query.After == nil || query.Compare(task, query.After.Task()) > 0
+	}
+
+	var tasks []domain.Task
+	var err error
+	switch {
+	case query.Sort == domain.SortByCreated || query.Sort == "":
+		tasks, err = r.queryByCreated(query, keep)
+	case query.Completed != nil:
+		tasks, err = r.findIndexed(boltByCompleted, completedPrefix(*query.Completed), keep)
+	default:
+		tasks, err = r.find(keep)
+	}
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	slices.SortFunc(tasks, query.Compare)
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		next := domain.CursorAt(page.Tasks[query.Limit-1])
+		page.Next = &next
+	}
+	return page, nil
+}
+
+// queryByCreated walks the creation time index in the query's direction,
+// starting at the cursor or the edge of the created range, and stops once it
+// has one task more than the page holds
+func (r *BoltTaskRepository) queryByCreated(query domain.TaskQuery, keep func(task domain.Task) bool) ([]domain.Task, error) {
+	x := r.resolver()
+	tasks := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltByCreated).Cursor()
+		var k []byte
+		step := c.Next
+		switch {
+		case !query.Desc && query.After != nil:
+			k, _ = c.Seek(createdKey(query.After.CreatedAt, query.After.ID))
+		case !query.Desc && query.CreatedAfter != nil:
+			k, _ = c.Seek(createdKey(*query.CreatedAfter, 0))
+		case !query.Desc:
+			k, _ = c.First()
+		default:
+			step = c.Prev
+			// Start at the last key before the cursor or the end of the range
+			var bound []byte
+			if query.After != nil {
+				bound = createdKey(query.After.CreatedAt, query.After.ID)
+			} else if query.CreatedBefore != nil {
+				bound = createdKey(*query.CreatedBefore, 0)
+			}
+			if k, _ = c.Last(); bound != nil {
+				if k, _ = c.Seek(bound); k == nil {
+					k, _ = c.Last()
+				} else {
+					k, _ = c.Prev()
+				}
+			}
+		}
+
+		for ; k != nil && len(tasks) <= query.Limit; k, _ = step() {
+			created := keyCreated(k)
+			if (!query.Desc && query.CreatedBefore != nil && !created.Before(*query.CreatedBefore)) ||
+				(query.Desc && query.CreatedAfter != nil && created.Before(*query.CreatedAfter)) {
+				break
+			}
+			task, _, err := getTask(tx, keyID(k))
+			if err != nil {
+				return err
+			}
+			if !keep(task) {
+				continue
+			}
+			if err := x.resolve(&task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+func (r *BoltTaskRepository) Search(terms []string, limit int) ([]domain.SearchHit, error) {
+	r.mutex.Lock()
+	ids, scores := r.index.search(terms)
+	r.mutex.Unlock()
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	// A task trashed since the index was searched is left out
+	tasks, err := r.findIDs(ids, live)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]domain.SearchHit, 0, len(tasks))
+	for _, task := range tasks {
+		hits = append(hits, domain.SearchHit{Task: task, Score: scores[task.ID]})
+	}
+	return hits, nil
+}
+
+func (r *BoltTaskRepository) FindByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		if !listed(task) {
+			return false
+		}
+		matched := 0
+		for _, name := range names {
+			for _, tag := range task.Tags {
+				if tag.Name == name {
+					matched++
+					break
+				}
+			}
+		}
+		return (matchAll && matched == len(names)) || (!matchAll && matched > 0)
+	})
+}
+
+// FindOpenDueBefore reads only the open tasks, through the completion index
+func (r *BoltTaskRepository) FindOpenDueBefore(before time.Time) ([]domain.Task, error) {
+	tasks, err := r.findIndexed(boltByCompleted, completedPrefix(false), func(task domain.Task) bool {
+		return live(task) && task.DueDate != nil && !task.DueDate.After(before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+	return tasks, nil
+}
+
+func (r *BoltTaskRepository) FindChildren(parentID uint) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		return live(task) && task.ParentID != nil && *task.ParentID == parentID
+	})
+}
+
+func (r *BoltTaskRepository) FindSeries(seriesID uint) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		return live(task) && task.SeriesRoot() == seriesID
+	})
+}
+
+func (r *BoltTaskRepository) FindByProject(projectID uint) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		return listed(task) && task.ProjectID != nil && *task.ProjectID == projectID
+	})
+}
+
+func (r *BoltTaskRepository) CountByProject() (map[uint]domain.TaskCounts, error) {
+	counts := make(map[uint]domain.TaskCounts)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return eachTask(tx, func(task domain.Task) error {
+			if !live(task) || task.ProjectID == nil {
+				return nil
+			}
+			c := counts[*task.ProjectID]
+			c.Total++
+			if !task.Completed {
+				c.Open++
+			}
+			counts[*task.ProjectID] = c
+			return nil
+		})
+	})
+	return counts, err
+}
+
+// blockerIDs returns the IDs of the tasks blocking the given task
+func blockerIDs(tx *bolt.Tx, taskID uint) []uint {
+	prefix := idKey(taskID)
+	ids := make([]uint, 0)
+	c := tx.Bucket(boltBlockers).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		ids = append(ids, keyID(k))
+	}
+	return ids
+}
+
+func (r *BoltTaskRepository) FindBlockers(taskID uint) ([]domain.Task, error) {
+	var ids []uint
+	err := r.db.View(func(tx *bolt.Tx) error {
+		ids = blockerIDs(tx, taskID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.findIDs(ids, live)
+}
+
+// FindReady reads only the open tasks, through the completion index
+func (r *BoltTaskRepository) FindReady() ([]domain.Task, error) {
+	x := r.resolver()
+	tasks := make([]domain.Task, 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		open := completedPrefix(false)
+		c := tx.Bucket(boltByCompleted).Cursor()
+		for k, _ := c.Seek(open); k != nil && bytes.HasPrefix(k, open); k, _ = c.Next() {
+			blocked := false
+			for _, id := range blockerIDs(tx, keyID(k)) {
+				if blocker, exists, err := getTask(tx, id); err != nil {
+					return err
+				} else if exists && live(blocker) && !blocker.Completed {
+					blocked = true
+					break
+				}
+			}
+			if blocked {
+				continue
+			}
+			task, _, err := getTask(tx, keyID(k))
+			if err != nil {
+				return err
+			}
+			if !live(task) {
+				continue
+			}
+			if err := x.resolve(&task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+func (r *BoltTaskRepository) FindByAssignee(userID uint) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		return listed(task) && task.AssigneeID != nil && *task.AssigneeID == userID
+	})
+}
+
+func (r *BoltTaskRepository) FindUnassigned() ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		return listed(task) && task.AssigneeID == nil
+	})
+}
+
+func (r *BoltTaskRepository) FindByFieldValues(values []domain.FieldValue) ([]domain.Task, error) {
+	return r.find(func(task domain.Task) bool {
+		if !listed(task) {
+			return false
+		}
+		for _, value := range values {
+			if held, ok := task.FieldValue(value.FieldID); !ok || !held.Equal(value) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (r *BoltTaskRepository) AddDependency(dependency domain.TaskDependency) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBlockers).Put(append(idKey(dependency.TaskID), idKey(dependency.BlockerID)...), nil)
+	})
+}
+
+func (r *BoltTaskRepository) RemoveDependency(taskID, blockerID uint) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		blockers := tx.Bucket(boltBlockers)
+		key := append(idKey(taskID), idKey(blockerID)...)
+		if blockers.Get(key) == nil {
+			return domain.ErrDependencyNotFound
+		}
+		return blockers.Delete(key)
+	})
+}
+
+// Update replaces a task, trashed or not, keeping its stored position and
+// leaving a trashed task in the trash
+func (r *BoltTaskRepository) Update(task domain.Task) (domain.Task, error) {
+	err := r.write(func(tx *bolt.Tx, changes *searchChanges) error {
+		old, exists, err := getTask(tx, task.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrTaskNotFound
+		}
+		task.Position = old.Position
+		task.DeletedAt = old.DeletedAt
+		if err := numberItems(tx, &task); err != nil {
+			return err
+		}
+		if err := putTask(tx, &old, task); err != nil {
+			return err
+		}
+		if live(task) {
+			changes.add(task)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return r.findOne(task.ID, func(domain.Task) bool { return true })
+}
+
+// Move places a task next to the anchor task, reading the neighbour from the
+// position index. Bolt allows one writer at a time, so concurrent moves
+// always see the positions the previous one committed.
+func (r *BoltTaskRepository) Move(id, anchorID uint, after bool) (domain.Task, error) {
+	err := r.write(func(tx *bolt.Tx, _ *searchChanges) error {
+		task, exists, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		anchor, anchored, err := getTask(tx, anchorID)
+		if err != nil {
+			return err
+		}
+		if !exists || !anchored || !live(task) || !live(anchor) || id == anchorID {
+			return domain.ErrTaskNotFound
+		}
+
+		position, ok := boltPositionNextTo(tx, id, anchor, after)
+		if !ok {
+			if err := boltRespace(tx); err != nil {
+				return err
+			}
+			if anchor, _, err = getTask(tx, anchorID); err != nil {
+				return err
+			}
+			if position, ok = boltPositionNextTo(tx, id, anchor, after); !ok {
+				return errors.New("no room left to move the task after respacing")
+			}
+		}
+		if task, _, err = getTask(tx, id); err != nil {
+			return err
+		}
+		old := task
+		task.Position = position
+		return putTask(tx, &old, task)
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return r.FindByID(id)
+}
+
+// boltPositionNextTo finds a free position directly before or after the
+// anchor, not counting the task being moved. It reports false when the anchor
+// and its neighbour are too close together.
+func boltPositionNextTo(tx *bolt.Tx, id uint, anchor domain.Task, after bool) (int64, bool) {
+	c := tx.Bucket(boltByPosition).Cursor()
+	c.Seek(positionKey(anchor.Position, anchor.ID))
+	step := c.Prev
+	if after {
+		step = c.Next
+	}
+	var neighbour *int64
+	for k, _ := step(); k != nil; k, _ = step() {
+		if keyID(k) != id {
+			position := keyPosition(k)
+			neighbour = &position
+			break
+		}
+	}
+	if after {
+		return domain.PositionBetween(&anchor.Position, neighbour)
+	}
+	return domain.PositionBetween(neighbour, &anchor.Position)
+}
+
+// boltRespace spreads every task PositionGap apart, keeping their current order
+func boltRespace(tx *bolt.Tx) error {
+	var ids []uint
+	err := tx.Bucket(boltByPosition).ForEach(func(k, _ []byte) error {
+		ids = append(ids, keyID(k))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		task, _, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		old := task
+		task.Position = int64(i+1) * domain.PositionGap
+		if err := putTask(tx, &old, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *BoltTaskRepository) FindArchived(query string) ([]domain.Task, error) {
+	query = strings.ToLower(query)
+	tasks, err := r.find(func(task domain.Task) bool {
+		if !live(task) || task.ArchivedAt == nil {
+			return false
+		}
+		return strings.Contains(strings.ToLower(task.Title), query) || strings.Contains(strings.ToLower(task.Description), query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].ArchivedAt.After(*tasks[j].ArchivedAt)
+	})
+	return tasks, nil
+}
+
+// ArchiveCompletedBefore reads only the completed tasks, through the completion index
+func (r *BoltTaskRepository) ArchiveCompletedBefore(before, at time.Time) ([]domain.Task, error) {
+	var archived []domain.Task
+	err := r.write(func(tx *bolt.Tx, _ *searchChanges) error {
+		done := completedPrefix(true)
+		var ids []uint
+		c := tx.Bucket(boltByCompleted).Cursor()
+		for k, _ := c.Seek(done); k != nil && bytes.HasPrefix(k, done); k, _ = c.Next() {
+			ids = append(ids, keyID(k))
+		}
+		for _, id := range ids {
+			task, _, err := getTask(tx, id)
+			if err != nil {
+				return err
+			}
+			if !live(task) || task.ArchivedAt != nil || task.CompletedAt == nil || !task.CompletedAt.Before(before) {
+				continue
+			}
+			old := task
+			task.ArchivedAt = &at
+			if err := putTask(tx, &old, task); err != nil {
+				return err
+			}
+			archived = append(archived, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+func (r *BoltTaskRepository) Trash(id uint, at time.Time) error {
+	return r.write(func(tx *bolt.Tx, changes *searchChanges) error {
+		task, exists, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists || !live(task) {
+			return domain.ErrTaskNotFound
+		}
+		old := task
+		task.DeletedAt = &at
+		if err := putTask(tx, &old, task); err != nil {
+			return err
+		}
+		changes.remove(id)
+		return nil
+	})
+}
+
+func (r *BoltTaskRepository) Restore(id uint) error {
+	return r.write(func(tx *bolt.Tx, changes *searchChanges) error {
+		task, exists, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists || live(task) {
+			return domain.ErrTaskNotFound
+		}
+		old := task
+		task.DeletedAt = nil
+		if err := putTask(tx, &old, task); err != nil {
+			return err
+		}
+		changes.add(task)
+		return nil
+	})
+}
+
+func (r *BoltTaskRepository) FindTrashed() ([]domain.Task, error) {
+	tasks, err := r.find(func(task domain.Task) bool {
+		return !live(task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DeletedAt.After(*tasks[j].DeletedAt)
+	})
+	return tasks, nil
+}
+
+func (r *BoltTaskRepository) FindTrashedByID(id uint) (domain.Task, error) {
+	return r.findOne(id, func(task domain.Task) bool {
+		return !live(task)
+	})
+}
+
+// Delete permanently removes a task along with the dependencies on either side of it
+func (r *BoltTaskRepository) Delete(id uint) error {
+	return r.write(func(tx *bolt.Tx, changes *searchChanges) error {
+		task, exists, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrTaskNotFound
+		}
+		if err := deleteIndexes(tx, task); err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltTasks).Delete(idKey(id)); err != nil {
+			return err
+		}
+
+		var stale [][]byte
+		err = tx.Bucket(boltBlockers).ForEach(func(k, _ []byte) error {
+			if keyID(k[:8]) == id || keyID(k) == id {
+				stale = append(stale, bytes.Clone(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err := tx.Bucket(boltBlockers).Delete(k); err != nil {
+				return err
+			}
+		}
+		changes.remove(id)
+		return nil
+	})
+}
@@ -0,0 +1,268 @@
+package infrastructure
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+func newBoltRepository(t *testing.T, path string, tags domain.TagRepository) *BoltTaskRepository {
+	t.Helper()
+	repo, err := NewBoltTaskRepository(path, tags, nil)
+	if err != nil {
+		t.Fatalf("failed to open bolt file: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestBolt_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.bolt")
+	repo := newBoltRepository(t, path, nil)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "Quarterly report", Checklist: []domain.ChecklistItem{{Text: "Collect numbers"}}},
+		domain.Task{Title: "Water plants"},
+	)
+	if ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("expected IDs 1 and 2, got %v", ids)
+	}
+	if err := repo.AddDependency(domain.TaskDependency{TaskID: ids[1], BlockerID: ids[0]}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	repo = newBoltRepository(t, path, nil)
+	task, err := repo.FindByID(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Title != "Quarterly report" || len(task.Checklist) != 1 || task.Checklist[0].ID != 1 || task.CreatedAt.IsZero() {
+		t.Errorf("task did not survive reopening: %+v", task)
+	}
+	blockers, err := repo.FindBlockers(ids[1])
+	if err != nil || len(blockers) != 1 || blockers[0].ID != ids[0] {
+		t.Errorf("expected the dependency to survive reopening, got %v, %v", blockers, err)
+	}
+
+	// IDs keep counting from where they were and the search index is rebuilt
+	id, _ := repo.Save(domain.Task{Title: "Report expenses"})
+	if id != 3 {
+		t.Errorf("expected ID 3, got %d", id)
+	}
+	hits, err := repo.Search([]string{"rep"}, 10)
+	if err != nil || len(hits) != 2 {
+		t.Errorf("expected both reports, got %+v, %v", hits, err)
+	}
+}
+
+func TestBolt_QueryMatchesMemory(t *testing.T) {
+	store := newBoltRepository(t, filepath.Join(t.TempDir(), "tasks.bolt"), nil)
+	memory := NewMockTaskRepository()
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	titles := []string{"banana", "Apple", "cherry", "apricot", "date", "Elder", "fig"}
+	for i, title := range titles {
+		task := domain.Task{
+			Title:     title,
+			Completed: i%3 == 0,
+			Priority:  domain.Priority(i % 2),
+			// Two tasks share each creation time, so ties are broken by ID
+			CreatedAt: start.Add(time.Duration(i/2) * time.Hour),
+		}
+		if i%3 != 2 {
+			// Tasks of the same priority are ordered by due date first
+			due := start.AddDate(0, 0, i%3)
+			task.DueDate = &due
+		}
+		saveTasks(t, store, task)
+		saveTasks(t, memory, task)
+	}
+	archived, _ := store.FindByID(7)
+	now := start.Add(48 * time.Hour)
+	archived.ArchivedAt = &now
+	store.Update(archived)
+	memory.Update(archived)
+
+	completed := true
+	after, before := start.Add(time.Hour), start.Add(3*time.Hour)
+	queries := []domain.TaskQuery{
+		{Limit: 2},
+		{Desc: true, Limit: 2},
+		{Completed: &completed, Limit: 2},
+		{CreatedAfter: &after, CreatedBefore: &before, Limit: 2},
+		{CreatedAfter: &after, CreatedBefore: &before, Desc: true, Limit: 2},
+		{Sort: domain.SortByTitle, Limit: 3},
+		{Sort: domain.SortByPriority, Desc: true, Completed: &completed, Limit: 1},
+		{Sort: domain.SortByPriority, Limit: 2},
+		{Text: "AP", Limit: 5},
+	}
+	for _, query := range queries {
+		// Page through both repositories and compare every page
+		for page := 1; ; page++ {
+			want, err := memory.Query(query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := store.Query(query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(taskIDs(got.Tasks), taskIDs(want.Tasks)) || (got.Next == nil) != (want.Next == nil) {
+				t.Fatalf("query %+v page %d: expected %v (more: %v), got %v (more: %v)",
+					query, page, taskIDs(want.Tasks), want.Next != nil, taskIDs(got.Tasks), got.Next != nil)
+			}
+			if want.Next == nil {
+				break
+			}
+			query.After = want.Next
+		}
+	}
+}
+
+// countingTagRepository counts the tag lookups made through it
+type countingTagRepository struct {
+	domain.TagRepository
+	byID, all int
+}
+
+func (r *countingTagRepository) FindByID(id uint) (domain.Tag, error) {
+	r.byID++
+	return r.TagRepository.FindByID(id)
+}
+
+func (r *countingTagRepository) FindAll() ([]domain.Tag, error) {
+	r.all++
+	return r.TagRepository.FindAll()
+}
+
+func TestBolt_ResolvesTags(t *testing.T) {
+	tags := &countingTagRepository{TagRepository: NewMockTagRepository(NewMockTaskRepository())}
+	home, _ := tags.Save(domain.Tag{Name: "home"})
+	work, _ := tags.Save(domain.Tag{Name: "work"})
+	tags.Save(domain.Tag{Name: "unused"})
+
+	repo := newBoltRepository(t, filepath.Join(t.TempDir(), "tasks.bolt"), tags)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "Paint fence", Tags: []domain.Tag{{ID: home, Name: "home"}}},
+		domain.Task{Title: "Send invoice", Tags: []domain.Tag{{ID: home, Name: "home"}, {ID: work, Name: "work"}}},
+		domain.Task{Title: "Water plants"},
+	)
+
+	// A read looks up each tag its tasks refer to once, and no others
+	if _, err := repo.FindAll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags.byID != 2 || tags.all != 0 {
+		t.Errorf("expected 2 tag lookups by ID and none of every tag, got %d and %d", tags.byID, tags.all)
+	}
+
+	// A renamed tag shows its new name and a deleted one disappears
+	tags.Update(domain.Tag{ID: home, Name: "house"})
+	tags.Delete(work)
+
+	task, err := repo.FindByID(ids[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(task.Tags) != 1 || task.Tags[0].Name != "house" {
+		t.Errorf("expected only the renamed tag, got %+v", task.Tags)
+	}
+	tasks, err := repo.FindByTags([]string{"house"}, false)
+	if err != nil || !slices.Equal(taskIDs(tasks), ids[:2]) {
+		t.Errorf("expected both tasks under the new name, got %v, %v", taskIDs(tasks), err)
+	}
+	tasks, _ = repo.FindByTags([]string{"work"}, false)
+	if len(tasks) != 0 {
+		t.Errorf("expected no tasks under the deleted tag, got %v", taskIDs(tasks))
+	}
+}
+
+func TestBolt_MoveTrashAndDelete(t *testing.T) {
+	repo := newBoltRepository(t, filepath.Join(t.TempDir(), "tasks.bolt"), nil)
+	ids := saveTasks(t, repo, domain.Task{Title: "first"}, domain.Task{Title: "second"}, domain.Task{Title: "third"})
+
+	// Moving the other two tasks in turn right after the first halves the gap
+	// behind it every time, until it runs out of room and the list is respaced
+	for i := 0; i < 40; i++ {
+		if _, err := repo.Move(ids[1+i%2], ids[0], true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	tasks := tasksByPosition(t, repo)
+	if got := taskIDs(tasks); !slices.Equal(got, []uint{ids[0], ids[2], ids[1]}) {
+		t.Errorf("unexpected order %v", got)
+	}
+	if gap := tasks[2].Position - tasks[1].Position; gap < 2 {
+		t.Errorf("expected the list to have been respaced, got positions %d and %d", tasks[1].Position, tasks[2].Position)
+	}
+
+	repo.AddDependency(domain.TaskDependency{TaskID: ids[1], BlockerID: ids[0]})
+	ready, _ := repo.FindReady()
+	if !slices.Equal(taskIDs(ready), []uint{ids[0], ids[2]}) {
+		t.Errorf("expected the blocked task to wait, got %v", taskIDs(ready))
+	}
+
+	at := time.Now()
+	if err := repo.Trash(ids[0], at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.FindByID(ids[0]); err != domain.ErrTaskNotFound {
+		t.Errorf("expected a trashed task to be hidden, got %v", err)
+	}
+	if hits, _ := repo.Search([]string{"first"}, 10); len(hits) != 0 {
+		t.Errorf("expected a trashed task to be left out of search, got %+v", hits)
+	}
+	trashed, _ := repo.FindTrashedByID(ids[0])
+	trashed.Title = "first, renamed"
+	trashed.DeletedAt = nil
+	if _, err := repo.Update(trashed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trashed, err := repo.FindTrashedByID(ids[0]); err != nil || trashed.Title != "first, renamed" {
+		t.Errorf("expected an updated task to stay in the trash, got %+v, %v", trashed, err)
+	}
+	if err := repo.Restore(ids[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits, _ := repo.Search([]string{"first"}, 10); len(hits) != 1 {
+		t.Errorf("expected a restored task to be searchable, got %+v", hits)
+	}
+
+	if err := repo.Delete(ids[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.RemoveDependency(ids[1], ids[0]); err != domain.ErrDependencyNotFound {
+		t.Errorf("expected deleting a task to drop its dependencies, got %v", err)
+	}
+	if err := repo.Delete(ids[0]); err != domain.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestBolt_KeepsZeroFieldValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.bolt")
+	repo := newBoltRepository(t, path, nil)
+	zero, empty := 0.0, ""
+	ids := saveTasks(t, repo, domain.Task{Title: "Count stock", FieldValues: []domain.FieldValue{
+		{FieldID: 1, NumberValue: &zero},
+		{FieldID: 2, TextValue: &empty},
+	}})
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	repo = newBoltRepository(t, path, nil)
+	task, err := repo.FindByID(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(task.FieldValues) != 2 || task.FieldValues[0].NumberValue == nil || *task.FieldValues[0].NumberValue != 0 ||
+		task.FieldValues[1].TextValue == nil || task.FieldValues[0].TaskID != ids[0] {
+		t.Errorf("expected the number set to 0 and the empty text to be kept, got %+v", task.FieldValues)
+	}
+}
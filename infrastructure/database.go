@@ -1,19 +1,38 @@
 package infrastructure
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
 	"github.com/krishnakumarkp/to-do/config"
+	"github.com/krishnakumarkp/to-do/domain"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// ConnectToDB establishes a connection to the database using the DSN from the configuration
+// ConnectToDB establishes a connection to the database the configuration
+// selects: MySQL or PostgreSQL through their DSN, or a SQLite file. The bolt
+// driver keeps everything but tasks in a SQLite file too.
 func ConnectToDB() (*gorm.DB, error) {
-	// Get the DSN from the global config
-	dsn := config.GetDSN()
+	var dialector gorm.Dialector
+	switch config.AppConfig.DBDriver {
+	case "sqlite", "bolt", "memory":
+		dialector = sqlite.Open(config.AppConfig.DBPath)
+	case "postgres":
+		dialector = postgres.Open(PostgresDSN())
+	default:
+		dialector = mysql.Open(config.GetDSN())
+	}
 
-	// Open a connection to the database
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// Open a connection to the database. Translating errors turns each
+	// database's own codes for constraint violations into gorm errors, such
+	// as gorm.ErrDuplicatedKey, which repositories check for.
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -21,3 +40,106 @@ func ConnectToDB() (*gorm.DB, error) {
 	// Return the DB instance
 	return db, nil
 }
+
+// PostgresDSN returns the PostgreSQL connection URL built from the config
+func PostgresDSN() string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(config.AppConfig.DBUser, config.AppConfig.DBPassword),
+		Host:   net.JoinHostPort(config.AppConfig.DBHost, config.AppConfig.DBPort),
+		Path:   config.AppConfig.DBName,
+	}
+	if config.AppConfig.DBSSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {config.AppConfig.DBSSLMode}}.Encode()
+	}
+	return dsn.String()
+}
+
+// NewTaskRepository returns the task repository for the database db is connected to
+func NewTaskRepository(db *gorm.DB) domain.TaskRepository {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return NewSQLiteTaskRepository(db)
+	case "postgres":
+		return NewPostgresTaskRepository(db)
+	default:
+		return NewMySQLTaskRepository(db)
+	}
+}
+
+// BackfillTaskStatus moves tasks completed before statuses existed, which the
+// migration gave the default todo status, to done
+func BackfillTaskStatus(db *gorm.DB) error {
+	return db.Model(&domain.Task{}).
+		Where("completed = ? AND status = ?", true, domain.StatusTodo).
+		Update("status", domain.StatusDone).Error
+}
+
+// searchIndexes are the MySQL FULLTEXT indexes MySQLTaskRepository.Search matches against
+var searchIndexes = []struct {
+	name    string
+	columns string
+}{
+	{"idx_tasks_search", "title, description"},
+	{"idx_tasks_search_title", "title"},
+}
+
+// CreateSearchIndexes adds the full-text indexes task search relies on when
+// they are missing. They are specific to each database, so they are made
+// here rather than declared on domain.Task.
+func CreateSearchIndexes(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return createSQLiteSearchTable(db)
+	case "postgres":
+		// Index expressions name the columns of the indexed table without qualifying them
+		document := strings.ReplaceAll(taskSearchDocument, "tasks.", "")
+		return db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN (%s)", document)).Error
+	}
+	for _, index := range searchIndexes {
+		if db.Migrator().HasIndex(&domain.Task{}, index.name) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf("CREATE FULLTEXT INDEX %s ON tasks (%s)", index.name, index.columns)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteSearchTriggers keep the tasks_search table in step with the tasks it indexes
+var sqliteSearchTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS tasks_search_insert AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_search (rowid, title, description) VALUES (new.id, new.title, new.description);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS tasks_search_delete AFTER DELETE ON tasks BEGIN
+		INSERT INTO tasks_search (tasks_search, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS tasks_search_update AFTER UPDATE OF title, description ON tasks BEGIN
+		INSERT INTO tasks_search (tasks_search, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+		INSERT INTO tasks_search (rowid, title, description) VALUES (new.id, new.title, new.description);
+	END`,
+}
+
+// createSQLiteSearchTable adds the FTS5 table SQLiteTaskRepository.Search
+// matches against, indexing the existing tasks when it is new
+func createSQLiteSearchTable(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		exists := tx.Migrator().HasTable("tasks_search")
+		if !exists {
+			err := tx.Exec("CREATE VIRTUAL TABLE tasks_search USING fts5(title, description, content='tasks', content_rowid='id')").Error
+			if err != nil {
+				return err
+			}
+		}
+		for _, trigger := range sqliteSearchTriggers {
+			if err := tx.Exec(trigger).Error; err != nil {
+				return err
+			}
+		}
+		if exists {
+			return nil
+		}
+		return tx.Exec("INSERT INTO tasks_search (tasks_search) VALUES ('rebuild')").Error
+	})
+}
@@ -0,0 +1,630 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormTaskRepository holds the task queries shared by the SQL databases,
+// which gorm translates for each of them. What the databases do differently
+// is left to its dialect.
+type gormTaskRepository struct {
+	db      *gorm.DB
+	dialect taskDialect
+}
+
+// taskDialect is what a gormTaskRepository leaves to the database it runs on
+type taskDialect interface {
+	// sortColumn returns the expression a sort field of a TaskQuery orders by;
+	// titles sort ignoring case
+	sortColumn(sort domain.TaskSort) string
+	// like returns the operator matching text against a LIKE pattern, ignoring case
+	like() string
+	// rank returns up to limit of the tasks outside the trash matching every
+	// search term, most relevant first
+	rank(db *gorm.DB, terms []string, limit int) ([]rankedTask, error)
+}
+
+// tasks returns a query on the tasks that are not in the trash, with their associations preloaded
+func (r *gormTaskRepository) tasks() *gorm.DB {
+	return r.withAssociations(r.db.Where("tasks.deleted_at IS NULL"))
+}
+
+// listed narrows tasks to those shown in default listings, leaving out the archive
+func (r *gormTaskRepository) listed() *gorm.DB {
+	return r.tasks().Where("tasks.archived_at IS NULL")
+}
+
+// withAssociations preloads the tags, ordered checklist and custom field values of the queried tasks
+func (r *gormTaskRepository) withAssociations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags").Preload("Checklist", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("FieldValues", func(db *gorm.DB) *gorm.DB {
+		return db.Order("field_id")
+	})
+}
+
+// Save stores a new task, placing it at the end of the manual order unless it
+// already has a position. Tasks saved concurrently may share a position; they
+// are then ordered by ID until one of them is moved.
+func (r *gormTaskRepository) Save(task domain.Task) (uint, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if task.Position == 0 {
+			var last int64
+			if err := tx.Model(&domain.Task{}).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+				return err
+			}
+			task.Position = last + domain.PositionGap
+		}
+		return tx.Create(&task).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return task.ID, nil
+}
+
+func (r *gormTaskRepository) FindByID(id uint) (domain.Task, error) {
+	var task domain.Task
+	result := r.tasks().First(&task, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return task, domain.ErrTaskNotFound
+	}
+	return task, result.Error
+}
+
+func (r *gormTaskRepository) FindAll() ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.listed().Find(&tasks)
+	return tasks, result.Error
+}
+
+// taskSortColumns maps each sort field of a TaskQuery to the column it
+// orders by, unless the dialect orders it differently
+var taskSortColumns = map[domain.TaskSort]string{
+	domain.SortByCreated:  "tasks.created_at",
+	domain.SortByTitle:    "tasks.title",
+	domain.SortByPriority: "tasks.priority",
+	domain.SortByPosition: "tasks.position",
+}
+
+// Query returns one page of the listed tasks, starting after the query's
+// cursor with a keyset condition so deep pages cost as little as the first.
+// One task past the limit is read to tell whether another page follows.
+func (r *gormTaskRepository) Query(query domain.TaskQuery) (domain.TaskPage, error) {
+	db := r.listed()
+	if query.Completed != nil {
+		db = db.Where("tasks.completed = ?", *query.Completed)
+	}
+	if query.CreatedAfter != nil {
+		db = db.Where("tasks.created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		db = db.Where("tasks.created_at < ?", *query.CreatedBefore)
+	}
+	if query.Text != "" {
+		pattern := "%" + likeEscaper.Replace(query.Text) + "%"
+		db = db.Where(fmt.Sprintf("tasks.title %[1]s ? ESCAPE '!' OR tasks.description %[1]s ? ESCAPE '!'", r.dialect.like()), pattern, pattern)
+	}
+
+	sort := query.Sort
+	if !sort.Valid() {
+		sort = domain.SortByCreated
+	}
+	column := r.dialect.sortColumn(sort)
+	direction, beyond := "ASC", ">"
+	if query.Desc {
+		direction, beyond = "DESC", "<"
+	}
+	order := []string{column + " " + direction}
+	if query.Sort == domain.SortByPriority {
+		order = append(order, dueDateOrder(query.Desc)...)
+	}
+	order = append(order, "tasks.id "+direction)
+	if query.After != nil {
+		key := query.After.Key(query.Sort)
+		tie, args := "tasks.id "+beyond+" ?", []any{query.After.ID}
+		if query.Sort == domain.SortByPriority {
+			tie, args = dueDateAfter(query.After.DueDate, query.Desc, tie, args)
+		}
+		db = db.Where(fmt.Sprintf("%s %s ? OR (%s = ? AND %s)", column, beyond, column, tie), append([]any{key, key}, args...)...)
+	}
+	for _, term := range order {
+		db = db.Order(term)
+	}
+
+	var tasks []domain.Task
+	result := db.Limit(query.Limit + 1).Find(&tasks)
+	if result.Error != nil {
+		return domain.TaskPage{}, result.Error
+	}
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		next := domain.CursorAt(page.Tasks[query.Limit-1])
+		page.Next = &next
+	}
+	return page, nil
+}
+
+// dueDateOrder returns the ORDER BY terms that order tasks of the same
+// priority by due date, the opposite way to the priorities: with the most
+// important first, the earliest due come first and those without a due date last
+func dueDateOrder(desc bool) []string {
+	if desc {
+		return []string{"tasks.due_date IS NULL", "tasks.due_date ASC"}
+	}
+	return []string{"tasks.due_date IS NULL DESC", "tasks.due_date DESC"}
+}
+
+// dueDateAfter extends the keyset condition tie, which holds for the tasks
+// after the cursor among those with its sort values, to the tasks of the
+// cursor's priority that come after its due date in the order of dueDateOrder
+func dueDateAfter(due *time.Time, desc bool, tie string, args []any) (string, []any) {
+	switch {
+	case desc && due == nil:
+		return "(tasks.due_date IS NULL AND " + tie + ")", args
+	case desc:
+		return "(tasks.due_date > ? OR tasks.due_date IS NULL OR (tasks.due_date = ? AND " + tie + "))", append([]any{*due, *due}, args...)
+	case due == nil:
+		return "(tasks.due_date IS NOT NULL OR " + tie + ")", args
+	default:
+		return "(tasks.due_date < ? OR (tasks.due_date = ? AND " + tie + "))", append([]any{*due, *due}, args...)
+	}
+}
+
+// Search ranks the tasks with the dialect's full-text index and loads them in that order
+func (r *gormTaskRepository) Search(terms []string, limit int) ([]domain.SearchHit, error) {
+	ranked, err := r.dialect.rank(r.db, terms, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.loadHits(ranked)
+}
+
+// rankedTask is a task ID and its relevance, as read by a search ranking query
+type rankedTask struct {
+	ID    uint
+	Score float64
+}
+
+// loadHits loads the ranked tasks, keeping the order they were ranked in
+func (r *gormTaskRepository) loadHits(ranked []rankedTask) ([]domain.SearchHit, error) {
+	if len(ranked) == 0 {
+		return []domain.SearchHit{}, nil
+	}
+	ids := make([]uint, len(ranked))
+	for i, hit := range ranked {
+		ids[i] = hit.ID
+	}
+	var tasks []domain.Task
+	if err := r.tasks().Where("tasks.id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	hits := make([]domain.SearchHit, 0, len(ranked))
+	for _, hit := range ranked {
+		// A task trashed between the two queries is left out
+		if task, ok := byID[hit.ID]; ok {
+			hits = append(hits, domain.SearchHit{Task: task, Score: hit.Score})
+		}
+	}
+	return hits, nil
+}
+
+// FindByTags returns tasks carrying any (or, with matchAll, every) of the named tags
+func (r *gormTaskRepository) FindByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	tagged := r.db.Table("task_tags").
+		Select("task_tags.task_id").
+		Joins("JOIN tags ON tags.id = task_tags.tag_id").
+		Where("tags.name IN ?", names).
+		Group("task_tags.task_id")
+	if matchAll {
+		tagged = tagged.Having("COUNT(DISTINCT tags.id) = ?", len(names))
+	}
+
+	var tasks []domain.Task
+	result := r.listed().Where("id IN (?)", tagged).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindOpenDueBefore returns incomplete tasks with a due date at or before the given time
+func (r *gormTaskRepository) FindOpenDueBefore(before time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.tasks().Where("completed = ? AND due_date IS NOT NULL AND due_date <= ?", false, before).
+		Order("due_date").
+		Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindChildren returns the direct subtasks of the given task
+func (r *gormTaskRepository) FindChildren(parentID uint) ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.tasks().Where("parent_id = ?", parentID).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindSeries returns every occurrence of a recurring series, the first task included
+func (r *gormTaskRepository) FindSeries(seriesID uint) ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.tasks().Where("id = ? OR series_id = ?", seriesID, seriesID).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindByProject returns the tasks belonging to a project
+func (r *gormTaskRepository) FindByProject(projectID uint) ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.listed().Where("project_id = ?", projectID).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// CountByProject returns the total and open task counts of every project that has tasks
+func (r *gormTaskRepository) CountByProject() (map[uint]domain.TaskCounts, error) {
+	var rows []struct {
+		ProjectID uint
+		Total     int64
+		OpenTasks int64
+	}
+	result := r.db.Model(&domain.Task{}).
+		Select("project_id, COUNT(*) AS total, SUM(CASE WHEN completed THEN 0 ELSE 1 END) AS open_tasks").
+		Where("project_id IS NOT NULL AND deleted_at IS NULL").
+		Group("project_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[uint]domain.TaskCounts, len(rows))
+	for _, row := range rows {
+		counts[row.ProjectID] = domain.TaskCounts{Total: row.Total, Open: row.OpenTasks}
+	}
+	return counts, nil
+}
+
+// FindBlockers returns the tasks the given task is waiting on
+func (r *gormTaskRepository) FindBlockers(taskID uint) ([]domain.Task, error) {
+	blockers := r.db.Table("task_dependencies").Select("blocker_id").Where("task_id = ?", taskID)
+
+	var tasks []domain.Task
+	result := r.tasks().Where("id IN (?)", blockers).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindReady returns the open tasks that have no open blockers left
+func (r *gormTaskRepository) FindReady() ([]domain.Task, error) {
+	openBlockers := r.db.Table("task_dependencies").
+		Select("task_dependencies.task_id").
+		Joins("JOIN tasks blockers ON blockers.id = task_dependencies.blocker_id").
+		Where("blockers.completed = ? AND blockers.deleted_at IS NULL", false)
+
+	var tasks []domain.Task
+	result := r.tasks().Where("completed = ? AND id NOT IN (?)", false, openBlockers).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindByAssignee returns the tasks assigned to a user
+func (r *gormTaskRepository) FindByAssignee(userID uint) ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.listed().Where("assignee_id = ?", userID).Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindUnassigned returns the tasks nobody is assigned to
+func (r *gormTaskRepository) FindUnassigned() ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.listed().Where("assignee_id IS NULL").Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindByFieldValues returns the tasks holding every one of the given custom field values
+func (r *gormTaskRepository) FindByFieldValues(values []domain.FieldValue) ([]domain.Task, error) {
+	matching := r.listed()
+	for _, value := range values {
+		holders := r.db.Model(&domain.FieldValue{}).Select("task_id").Where("field_id = ?", value.FieldID)
+		switch {
+		case value.TextValue != nil:
+			holders = holders.Where("text_value = ?", *value.TextValue)
+		case value.NumberValue != nil:
+			holders = holders.Where("number_value = ?", *value.NumberValue)
+		case value.DateValue != nil:
+			holders = holders.Where("date_value = ?", *value.DateValue)
+		}
+		matching = matching.Where("id IN (?)", holders)
+	}
+
+	var tasks []domain.Task
+	result := matching.Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// AddDependency records that a task is blocked by another; adding an existing dependency is a no-op
+func (r *gormTaskRepository) AddDependency(dependency domain.TaskDependency) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dependency).Error
+}
+
+// RemoveDependency removes the given blocker from a task
+func (r *gormTaskRepository) RemoveDependency(taskID, blockerID uint) error {
+	result := r.db.Where("task_id = ? AND blocker_id = ?", taskID, blockerID).Delete(&domain.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// FindArchived returns the archived tasks whose title or description contains
+// query, most recently archived first; an empty query matches every archived task
+func (r *gormTaskRepository) FindArchived(query string) ([]domain.Task, error) {
+	archived := r.tasks().Where("tasks.archived_at IS NOT NULL")
+	if query != "" {
+		pattern := "%" + likeEscaper.Replace(query) + "%"
+		archived = archived.Where(fmt.Sprintf("title %[1]s ? ESCAPE '!' OR description %[1]s ? ESCAPE '!'", r.dialect.like()), pattern, pattern)
+	}
+
+	var tasks []domain.Task
+	result := archived.Order("archived_at DESC").Order("id").Find(&tasks)
+	return tasks, result.Error
+}
+
+// likeEscaper escapes the LIKE wildcards in user input so it is matched
+// literally. Queries name '!' as the escape character, since not every
+// database treats a backslash as one by default.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// ArchiveCompletedBefore archives every completed task finished before the given time
+func (r *gormTaskRepository) ArchiveCompletedBefore(before, at time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := r.withAssociations(tx).
+			Where("completed = ? AND completed_at < ? AND archived_at IS NULL AND deleted_at IS NULL", true, before).
+			Order("id").
+			Find(&tasks).Error
+		if err != nil || len(tasks) == 0 {
+			return err
+		}
+		ids := make([]uint, len(tasks))
+		for i := range tasks {
+			ids[i] = tasks[i].ID
+			tasks[i].ArchivedAt = &at
+		}
+		return tx.Model(&domain.Task{}).Where("id IN ?", ids).UpdateColumn("archived_at", at).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// UpdateTask updates a task in the database
+func (r *gormTaskRepository) Update(task domain.Task) (domain.Task, error) {
+	// Use GORM's Save method to update the task row, then sync the tag links and checklist to match the task.
+	// The position is left out so a stale copy of the task cannot undo a concurrent Move.
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "position").Save(&task).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Task{}).Select("position").Where("id = ?", task.ID).Scan(&task.Position).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&task).Association("Tags").Replace(task.Tags); err != nil {
+			return err
+		}
+		if err := syncChecklist(tx, &task); err != nil {
+			return err
+		}
+		return syncFieldValues(tx, &task)
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+// syncFieldValues removes the custom field values the task no longer has and upserts the rest
+func syncFieldValues(tx *gorm.DB, task *domain.Task) error {
+	keep := make([]uint, 0, len(task.FieldValues))
+	for i := range task.FieldValues {
+		task.FieldValues[i].TaskID = task.ID
+		keep = append(keep, task.FieldValues[i].FieldID)
+	}
+	stale := tx.Where("task_id = ?", task.ID)
+	if len(keep) > 0 {
+		stale = stale.Where("field_id NOT IN ?", keep)
+	}
+	if err := stale.Delete(&domain.FieldValue{}).Error; err != nil {
+		return err
+	}
+	if len(task.FieldValues) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&task.FieldValues).Error
+}
+
+// Move places a task next to the anchor task. The moved and anchor rows are
+// locked before the neighbour is read, so concurrent moves around the same
+// tasks take turns and each one sees the positions the previous one committed.
+func (r *gormTaskRepository) Move(id, anchorID uint, after bool) (domain.Task, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []domain.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id IN ? AND deleted_at IS NULL", []uint{id, anchorID}).
+			Order("id").
+			Find(&locked).Error
+		if err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return domain.ErrTaskNotFound
+		}
+
+		position, ok, err := positionNextTo(tx, id, anchorID, after)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := respace(tx); err != nil {
+				return err
+			}
+			if position, ok, err = positionNextTo(tx, id, anchorID, after); err != nil {
+				return err
+			} else if !ok {
+				return errors.New("no room left to move the task after respacing")
+			}
+		}
+		return tx.Model(&domain.Task{}).Where("id = ?", id).UpdateColumn("position", position).Error
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return r.FindByID(id)
+}
+
+// positionNextTo finds a free position directly before or after the anchor,
+// not counting the task being moved, and locks the neighbour it is taken from.
+// It reports false when the anchor and its neighbour are too close together.
+func positionNextTo(tx *gorm.DB, id, anchorID uint, after bool) (int64, bool, error) {
+	var anchor int64
+	if err := tx.Model(&domain.Task{}).Select("position").Where("id = ?", anchorID).Scan(&anchor).Error; err != nil {
+		return 0, false, err
+	}
+
+	neighbours := tx.Model(&domain.Task{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id <> ?", id)
+	if after {
+		neighbours = neighbours.Where("position > ? OR (position = ? AND id > ?)", anchor, anchor, anchorID).
+			Order("position").Order("id")
+	} else {
+		neighbours = neighbours.Where("position < ? OR (position = ? AND id < ?)", anchor, anchor, anchorID).
+			Order("position DESC").Order("id DESC")
+	}
+	var found []int64
+	if err := neighbours.Limit(1).Pluck("position", &found).Error; err != nil {
+		return 0, false, err
+	}
+
+	var neighbour *int64
+	if len(found) > 0 {
+		neighbour = &found[0]
+	}
+	if after {
+		position, ok := domain.PositionBetween(&anchor, neighbour)
+		return position, ok, nil
+	}
+	position, ok := domain.PositionBetween(neighbour, &anchor)
+	return position, ok, nil
+}
+
+// respace spreads every task PositionGap apart, keeping their current order
+func respace(tx *gorm.DB) error {
+	var ids []uint
+	err := tx.Model(&domain.Task{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("position").Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if err := tx.Model(&domain.Task{}).Where("id = ?", id).UpdateColumn("position", int64(i+1)*domain.PositionGap).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncChecklist removes checklist rows the task no longer has and saves the rest
+func syncChecklist(tx *gorm.DB, task *domain.Task) error {
+	keep := make([]uint, 0, len(task.Checklist))
+	for _, item := range task.Checklist {
+		if item.ID != 0 {
+			keep = append(keep, item.ID)
+		}
+	}
+	stale := tx.Where("task_id = ?", task.ID)
+	if len(keep) > 0 {
+		stale = stale.Where("id NOT IN ?", keep)
+	}
+	if err := stale.Delete(&domain.ChecklistItem{}).Error; err != nil {
+		return err
+	}
+	for i := range task.Checklist {
+		task.Checklist[i].TaskID = task.ID
+		if err := tx.Save(&task.Checklist[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Trash marks a task as deleted without removing any of its data
+func (r *gormTaskRepository) Trash(id uint, at time.Time) error {
+	result := r.db.Model(&domain.Task{}).Where("id = ? AND deleted_at IS NULL", id).UpdateColumn("deleted_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTaskNotFound
+	}
+	return nil
+}
+
+// Restore takes a task back out of the trash
+func (r *gormTaskRepository) Restore(id uint) error {
+	result := r.db.Model(&domain.Task{}).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTaskNotFound
+	}
+	return nil
+}
+
+// FindTrashed returns the tasks in the trash, most recently deleted first
+func (r *gormTaskRepository) FindTrashed() ([]domain.Task, error) {
+	var tasks []domain.Task
+	result := r.withAssociations(r.db.Where("tasks.deleted_at IS NOT NULL")).
+		Order("deleted_at DESC").
+		Order("id").
+		Find(&tasks)
+	return tasks, result.Error
+}
+
+// FindTrashedByID returns a task that is in the trash
+func (r *gormTaskRepository) FindTrashedByID(id uint) (domain.Task, error) {
+	var task domain.Task
+	result := r.withAssociations(r.db.Where("tasks.deleted_at IS NOT NULL")).First(&task, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return task, domain.ErrTaskNotFound
+	}
+	return task, result.Error
+}
+
+func (r *gormTaskRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ? OR blocker_id = ?", id, id).Delete(&domain.TaskDependency{}).Error; err != nil {
+			return err
+		}
+		// Selecting the associations removes the task's join rows, checklist and field values along with the task itself
+		result := tx.Select("Tags", "Checklist", "FieldValues").Delete(&domain.Task{ID: id})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrTaskNotFound
+		}
+		return nil
+	})
+}
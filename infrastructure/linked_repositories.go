@@ -0,0 +1,54 @@
+package infrastructure
+
+import "github.com/krishnakumarkp/to-do/domain"
+
+// linkedTagRepository passes renamed and deleted tags on to the tasks of a
+// MemoryTaskRepository, which keep a copy of their tags
+type linkedTagRepository struct {
+	domain.TagRepository
+	tasks *MemoryTaskRepository
+}
+
+// NewLinkedTagRepository links tags stored elsewhere to a MemoryTaskRepository,
+// as MemoryTagRepository links its own
+func NewLinkedTagRepository(tags domain.TagRepository, tasks *MemoryTaskRepository) domain.TagRepository {
+	return &linkedTagRepository{TagRepository: tags, tasks: tasks}
+}
+
+func (r *linkedTagRepository) Update(tag domain.Tag) (domain.Tag, error) {
+	updated, err := r.TagRepository.Update(tag)
+	if err != nil {
+		return updated, err
+	}
+	if err := r.tasks.replaceTag(updated, true); err != nil {
+		return domain.Tag{}, err
+	}
+	return updated, nil
+}
+
+func (r *linkedTagRepository) Delete(id uint) error {
+	if err := r.TagRepository.Delete(id); err != nil {
+		return err
+	}
+	return r.tasks.replaceTag(domain.Tag{ID: id}, false)
+}
+
+// linkedCustomFieldRepository drops the values of deleted custom fields from
+// the tasks of a MemoryTaskRepository
+type linkedCustomFieldRepository struct {
+	domain.CustomFieldRepository
+	tasks *MemoryTaskRepository
+}
+
+// NewLinkedCustomFieldRepository links custom fields stored elsewhere to a
+// MemoryTaskRepository, as MemoryCustomFieldRepository links its own
+func NewLinkedCustomFieldRepository(fields domain.CustomFieldRepository, tasks *MemoryTaskRepository) domain.CustomFieldRepository {
+	return &linkedCustomFieldRepository{CustomFieldRepository: fields, tasks: tasks}
+}
+
+func (r *linkedCustomFieldRepository) Delete(id uint) error {
+	if err := r.CustomFieldRepository.Delete(id); err != nil {
+		return err
+	}
+	return r.tasks.removeFieldValues(id)
+}
@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+func TestLocalBlobStorage(t *testing.T) {
+	storage, err := NewLocalBlobStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	if err := storage.Put("tasks/1/abc", strings.NewReader("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := storage.Open("tasks/1/abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "hello" {
+		t.Errorf("expected content %q, got %q", "hello", data)
+	}
+
+	if err := storage.Delete("tasks/1/abc"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := storage.Open("tasks/1/abc"); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+	// Deleting twice is fine
+	if err := storage.Delete("tasks/1/abc"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLocalBlobStorage_RejectsEscapingKeys(t *testing.T) {
+	storage, err := NewLocalBlobStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	for _, key := range []string{"../outside", "/etc/passwd", "tasks/../../outside", ""} {
+		if err := storage.Put(key, strings.NewReader("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
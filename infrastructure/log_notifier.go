@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// LogNotifier writes fired reminders to a logger
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier logs to the given logger, or to the standard logger when nil
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	task := notification.Task
+	if notification.Recipient != nil {
+		n.logger.Printf("Reminder %d: task %d %q is due for %s <%s>", notification.Reminder.ID, task.ID, task.Title, notification.Recipient.Name, notification.Recipient.Email)
+		return nil
+	}
+	n.logger.Printf("Reminder %d: task %d %q", notification.Reminder.ID, task.ID, task.Title)
+	return nil
+}
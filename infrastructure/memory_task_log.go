@@ -0,0 +1,378 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// Files a durable MemoryTaskRepository keeps in its directory
+const (
+	taskLogFile      = "tasks.log"
+	taskSnapshotFile = "tasks.snapshot"
+)
+
+// taskRecordHeaderSize is the length and CRC-32 checksum written before every logged change
+const taskRecordHeaderSize = 8
+
+var errNoTaskLog = errors.New("task repository is not durable")
+
+// taskChange is everything one write to a MemoryTaskRepository changes: the
+// new state of the tasks it touched, the tasks deleted for good, the
+// dependencies added or removed and the ID counters. Writes build a change
+// and apply it, and opening a durable repository replays the logged ones, so
+// both go through the same code.
+type taskChange struct {
+	Seq        uint64 // Number of the change in the log, set when it is logged
+	Put        []storedTask
+	Delete     []uint
+	Block      []domain.TaskDependency
+	Unblock    []domain.TaskDependency
+	NextID     uint
+	NextItemID uint
+}
+
+// storedTask is a task and whether it is kept in the trash
+type storedTask struct {
+	Task    domain.Task
+	Trashed bool
+}
+
+// storedTaskRecord is the form a storedTask is logged in; see taskRecord
+type storedTaskRecord struct {
+	Task    taskRecord
+	Trashed bool
+}
+
+func (s storedTask) MarshalJSON() ([]byte, error) {
+	return json.Marshal(storedTaskRecord{Task: recordOf(s.Task), Trashed: s.Trashed})
+}
+
+func (s *storedTask) UnmarshalJSON(data []byte) error {
+	var record storedTaskRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+	*s = storedTask{Task: record.Task.task(), Trashed: record.Trashed}
+	return nil
+}
+
+func (c taskChange) empty() bool {
+	return len(c.Put) == 0 && len(c.Delete) == 0 && len(c.Block) == 0 && len(c.Unblock) == 0
+}
+
+// taskSnapshot is the whole state of a MemoryTaskRepository after the change numbered Seq
+type taskSnapshot struct {
+	Seq        uint64
+	Tasks      []storedTask
+	Blockers   []domain.TaskDependency
+	NextID     uint
+	NextItemID uint
+}
+
+// taskLog is the append-only file the changes of a durable MemoryTaskRepository
+// are written to before they are applied
+type taskLog struct {
+	dir     string
+	file    *os.File
+	size    int64  // Length of the valid records in the file
+	seq     uint64 // Number of the last change logged
+	pending int    // Changes logged since the last snapshot
+}
+
+// OpenMemoryTaskRepository returns a MemoryTaskRepository that keeps its tasks
+// across restarts in the given directory. Every change is appended to a log
+// and synced to disk before it is applied, and Snapshot compacts the log into
+// a snapshot of the whole state. Opening loads the snapshot and replays the log
+// written after it; a change left incomplete at the end of the log by a crash
+// was never applied and is dropped.
+func OpenMemoryTaskRepository(dir string) (*MemoryTaskRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	r := NewMockTaskRepository()
+
+	var seq uint64
+	data, err := os.ReadFile(filepath.Join(dir, taskSnapshotFile))
+	switch {
+	case err == nil:
+		var snapshot taskSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to read task snapshot: %w", err)
+		}
+		r.replay(taskChange{Put: snapshot.Tasks, Block: snapshot.Blockers, NextID: snapshot.NextID, NextItemID: snapshot.NextItemID})
+		seq = snapshot.Seq
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, taskLogFile), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	changes, size, err := readTaskLog(file)
+	if err == nil {
+		err = truncateTaskLog(file, size)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	pending := 0
+	for _, change := range changes {
+		// Changes up to the snapshot are left over from a compaction cut short
+		if change.Seq <= seq {
+			continue
+		}
+		r.replay(change)
+		seq = change.Seq
+		pending++
+	}
+	r.log = &taskLog{dir: dir, file: file, size: size, seq: seq, pending: pending}
+	return r, nil
+}
+
+// readTaskLog reads the changes in the log up to the first incomplete or
+// damaged record, and returns them with the length they take up
+func readTaskLog(file *os.File) ([]taskChange, int64, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	changes := make([]taskChange, 0)
+	offset := 0
+	for len(data)-offset >= taskRecordHeaderSize {
+		length := int(binary.LittleEndian.Uint32(data[offset:]))
+		checksum := binary.LittleEndian.Uint32(data[offset+4:])
+		start := offset + taskRecordHeaderSize
+		if length > len(data)-start || crc32.ChecksumIEEE(data[start:start+length]) != checksum {
+			break
+		}
+		var change taskChange
+		if err := json.Unmarshal(data[start:start+length], &change); err != nil {
+			break
+		}
+		changes = append(changes, change)
+		offset = start + length
+	}
+	if offset < len(data) {
+		log.Printf("Dropping %d bytes of incomplete changes at the end of %s", len(data)-offset, file.Name())
+	}
+	return changes, int64(offset), nil
+}
+
+// truncateTaskLog cuts the log back to the given length, so new changes follow the last valid one
+func truncateTaskLog(file *os.File, size int64) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == size {
+		return nil
+	}
+	if err := file.Truncate(size); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// append writes the change to the end of the log and syncs it to disk
+func (l *taskLog) append(change taskChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	record := make([]byte, taskRecordHeaderSize, taskRecordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload))
+	record = append(record, payload...)
+
+	_, err = l.file.Write(record)
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		// Drop whatever part of the record was written, so it cannot hide the changes after it
+		l.file.Truncate(l.size)
+		return err
+	}
+	l.size += int64(len(record))
+	l.seq = change.Seq
+	l.pending++
+	return nil
+}
+
+// apply writes the change to the log, when the repository has one, and then
+// to memory. A change that cannot be logged is not applied.
+func (r *MemoryTaskRepository) apply(change taskChange) error {
+	if change.empty() {
+		return nil
+	}
+	if r.log != nil {
+		change.Seq = r.log.seq + 1
+		if err := r.log.append(change); err != nil {
+			return fmt.Errorf("failed to log task change: %w", err)
+		}
+	}
+	r.replay(change)
+	return nil
+}
+
+// replay applies a change to memory
+func (r *MemoryTaskRepository) replay(change taskChange) {
+	for _, stored := range change.Put {
+		task := stored.Task
+		delete(r.tasks, task.ID)
+		delete(r.trashed, task.ID)
+		if stored.Trashed {
+			r.trashed[task.ID] = task
+			r.index.remove(task.ID)
+		} else {
+			r.tasks[task.ID] = task
+			r.index.add(task)
+		}
+	}
+	for _, id := range change.Delete {
+		delete(r.tasks, id)
+		delete(r.trashed, id)
+		delete(r.blockers, id)
+		r.index.remove(id)
+		for _, blockers := range r.blockers {
+			delete(blockers, id)
+		}
+	}
+	for _, dependency := range change.Block {
+		if r.blockers[dependency.TaskID] == nil {
+			r.blockers[dependency.TaskID] = make(map[uint]bool)
+		}
+		r.blockers[dependency.TaskID][dependency.BlockerID] = true
+	}
+	for _, dependency := range change.Unblock {
+		delete(r.blockers[dependency.TaskID], dependency.BlockerID)
+	}
+	r.nextID = max(r.nextID, change.NextID)
+	r.nextItemID = max(r.nextItemID, change.NextItemID)
+}
+
+// Snapshot writes the whole state of a durable repository to its snapshot
+// file and empties the log. Writes wait until it is done.
+func (r *MemoryTaskRepository) Snapshot() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.log == nil {
+		return errNoTaskLog
+	}
+	if r.log.pending == 0 {
+		return nil
+	}
+	snapshot := taskSnapshot{Seq: r.log.seq, NextID: r.nextID, NextItemID: r.nextItemID}
+	for _, task := range r.ordered() {
+		snapshot.Tasks = append(snapshot.Tasks, r.stored(task))
+	}
+	for taskID, blockers := range r.blockers {
+		for blockerID := range blockers {
+			snapshot.Blockers = append(snapshot.Blockers, domain.TaskDependency{TaskID: taskID, BlockerID: blockerID})
+		}
+	}
+	sort.Slice(snapshot.Blockers, func(i, j int) bool {
+		if snapshot.Blockers[i].TaskID != snapshot.Blockers[j].TaskID {
+			return snapshot.Blockers[i].TaskID < snapshot.Blockers[j].TaskID
+		}
+		return snapshot.Blockers[i].BlockerID < snapshot.Blockers[j].BlockerID
+	})
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := writeFileSynced(r.log.dir, taskSnapshotFile, data); err != nil {
+		return fmt.Errorf("failed to write task snapshot: %w", err)
+	}
+
+	// The snapshot holds every logged change now; should emptying the log
+	// fail, replaying it skips them by their numbers
+	if err := r.log.file.Truncate(0); err != nil {
+		return err
+	}
+	if err := r.log.file.Sync(); err != nil {
+		return err
+	}
+	r.log.size = 0
+	r.log.pending = 0
+	return nil
+}
+
+// writeFileSynced replaces the named file in dir with data, through a
+// temporary file renamed over it, so a crash leaves either the old or the new
+// contents
+func writeFileSynced(dir, name string, data []byte) error {
+	temp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	_, err = temp.Write(data)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(temp.Name(), filepath.Join(dir, name)); err != nil {
+		return err
+	}
+	// Sync the directory too, so the rename itself survives a crash
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// RunSnapshots takes a snapshot every interval until the context is cancelled,
+// and a last one then, so the log stays short and the next start has little to
+// replay
+func (r *MemoryTaskRepository) RunSnapshots(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Snapshot(); err != nil {
+				log.Printf("Failed to snapshot tasks: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+		if err := r.Snapshot(); err != nil {
+			log.Printf("Failed to snapshot tasks: %v", err)
+		}
+	}
+}
+
+// Close closes the log of a durable repository. Writes fail once it is closed.
+func (r *MemoryTaskRepository) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.log == nil {
+		return nil
+	}
+	return r.log.file.Close()
+}
@@ -0,0 +1,213 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+func openMemoryRepository(t *testing.T, dir string) *MemoryTaskRepository {
+	t.Helper()
+	repo, err := OpenMemoryTaskRepository(dir)
+	if err != nil {
+		t.Fatalf("failed to open task log: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+// reopen closes the repository and opens its directory again, as a restart would
+func reopen(t *testing.T, repo *MemoryTaskRepository, dir string) *MemoryTaskRepository {
+	t.Helper()
+	if err := repo.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	return openMemoryRepository(t, dir)
+}
+
+func TestMemoryLog_ReplaysWritesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	repo := openMemoryRepository(t, dir)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "Quarterly report", Checklist: []domain.ChecklistItem{{Text: "Collect numbers"}}},
+		domain.Task{Title: "Water plants", Tags: []domain.Tag{{ID: 4, Name: "home"}}},
+		domain.Task{Title: "Old invoice"},
+	)
+	repo.AddDependency(domain.TaskDependency{TaskID: ids[1], BlockerID: ids[0]})
+	task, _ := repo.FindByID(ids[0])
+	task.Completed = true
+	repo.Update(task)
+	repo.Move(ids[2], ids[0], false)
+	repo.Trash(ids[2], time.Now())
+	repo.replaceTag(domain.Tag{ID: 4, Name: "house"}, true)
+
+	repo = reopen(t, repo, dir)
+	task, err := repo.FindByID(ids[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !task.Completed || len(task.Checklist) != 1 || task.Checklist[0].ID != 1 {
+		t.Errorf("task did not survive restarting: %+v", task)
+	}
+	if task, _ := repo.FindByID(ids[1]); len(task.Tags) != 1 || task.Tags[0].Name != "house" {
+		t.Errorf("expected the renamed tag, got %+v", task.Tags)
+	}
+	if blockers, _ := repo.FindBlockers(ids[1]); len(blockers) != 1 || blockers[0].ID != ids[0] {
+		t.Errorf("expected the dependency to survive restarting, got %v", taskIDs(blockers))
+	}
+	if _, err := repo.FindTrashedByID(ids[2]); err != nil {
+		t.Errorf("expected the task to still be in the trash, got %v", err)
+	}
+	if err := repo.Restore(ids[2]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tasks := tasksByPosition(t, repo)
+	if got := taskIDs(tasks); !slices.Equal(got, []uint{ids[2], ids[0], ids[1]}) {
+		t.Errorf("expected the moved order to survive restarting, got %v", got)
+	}
+
+	// IDs keep counting from where they were and the search index is rebuilt
+	ids = saveTasks(t, repo, domain.Task{Title: "Report expenses", Checklist: []domain.ChecklistItem{{Text: "Receipts"}}})
+	if ids[0] != 4 {
+		t.Errorf("expected ID 4, got %d", ids[0])
+	}
+	if task, _ := repo.FindByID(ids[0]); task.Checklist[0].ID != 2 {
+		t.Errorf("expected checklist item ID 2, got %d", task.Checklist[0].ID)
+	}
+	if hits, _ := repo.Search([]string{"rep"}, 10); len(hits) != 2 {
+		t.Errorf("expected both reports, got %+v", hits)
+	}
+}
+
+func TestMemoryLog_SnapshotCompactsTheLog(t *testing.T) {
+	dir := t.TempDir()
+	repo := openMemoryRepository(t, dir)
+	ids := saveTasks(t, repo, domain.Task{Title: "first"}, domain.Task{Title: "second"})
+	repo.AddDependency(domain.TaskDependency{TaskID: ids[1], BlockerID: ids[0]})
+	if err := repo.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, taskLogFile)); err != nil || info.Size() != 0 {
+		t.Fatalf("expected the snapshot to empty the log, got %v, %v", info, err)
+	}
+
+	// Changes after the snapshot are replayed on top of it
+	repo.Delete(ids[0])
+	saveTasks(t, repo, domain.Task{Title: "third"})
+
+	repo = reopen(t, repo, dir)
+	tasks := tasksByPosition(t, repo)
+	if got := taskIDs(tasks); !slices.Equal(got, []uint{ids[1], 3}) {
+		t.Errorf("expected tasks 2 and 3, got %v", got)
+	}
+	if err := repo.RemoveDependency(ids[1], ids[0]); err != domain.ErrDependencyNotFound {
+		t.Errorf("expected deleting the blocker to drop the dependency, got %v", err)
+	}
+}
+
+func TestMemoryLog_SkipsChangesInTheSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	repo := openMemoryRepository(t, dir)
+	ids := saveTasks(t, repo, domain.Task{Title: "first"})
+	repo.Trash(ids[0], time.Now())
+	logged, err := os.ReadFile(filepath.Join(dir, taskLogFile))
+	if err != nil {
+		t.Fatalf("failed to read the log: %v", err)
+	}
+	repo.Snapshot()
+	repo.Restore(ids[0])
+	repo.Snapshot()
+
+	// A compaction cut short leaves changes the snapshot already holds in the
+	// log; replaying them would send the task back to the trash
+	if err := os.WriteFile(filepath.Join(dir, taskLogFile), logged, 0o644); err != nil {
+		t.Fatalf("failed to write the log: %v", err)
+	}
+	repo = reopen(t, repo, dir)
+	if _, err := repo.FindByID(ids[0]); err != nil {
+		t.Errorf("expected the restored task, got %v", err)
+	}
+}
+
+func TestMemoryLog_DropsIncompleteChange(t *testing.T) {
+	dir := t.TempDir()
+	repo := openMemoryRepository(t, dir)
+	saveTasks(t, repo, domain.Task{Title: "first"}, domain.Task{Title: "second"})
+	repo.Close()
+
+	// Cut the last record short, as a crash in the middle of writing it would
+	path := filepath.Join(dir, taskLogFile)
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatalf("failed to truncate the log: %v", err)
+	}
+
+	repo = openMemoryRepository(t, dir)
+	tasks, _ := repo.FindAll()
+	if got := taskIDs(tasks); !slices.Equal(got, []uint{1}) {
+		t.Fatalf("expected only the complete change, got %v", got)
+	}
+
+	// New changes follow the last complete one and are read back after it
+	ids := saveTasks(t, repo, domain.Task{Title: "again"})
+	if ids[0] != 2 {
+		t.Errorf("expected ID 2, got %d", ids[0])
+	}
+	repo = reopen(t, repo, dir)
+	if task, err := repo.FindByID(2); err != nil || task.Title != "again" {
+		t.Errorf("expected the change written after the damaged one, got %+v, %v", task, err)
+	}
+}
+
+func TestMemoryLog_ClosedRepositoryRejectsWrites(t *testing.T) {
+	repo := openMemoryRepository(t, t.TempDir())
+	repo.Close()
+	if _, err := repo.Save(domain.Task{Title: "lost"}); err == nil {
+		t.Fatal("expected an error writing to a closed log")
+	}
+	if tasks, _ := repo.FindAll(); len(tasks) != 0 {
+		t.Errorf("expected a change that was not logged not to be applied, got %v", taskIDs(tasks))
+	}
+	if err := NewMockTaskRepository().Snapshot(); err != errNoTaskLog {
+		t.Errorf("expected errNoTaskLog, got %v", err)
+	}
+}
+
+func TestMemoryLog_KeepsZeroFieldValues(t *testing.T) {
+	dir := t.TempDir()
+	repo := openMemoryRepository(t, dir)
+	zero, empty := 0.0, ""
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "logged", FieldValues: []domain.FieldValue{{FieldID: 1, NumberValue: &zero}, {FieldID: 2, TextValue: &empty}}},
+		domain.Task{Title: "snapshotted", FieldValues: []domain.FieldValue{{FieldID: 1, NumberValue: &zero}}},
+	)
+
+	check := func(id uint) {
+		t.Helper()
+		task, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		number, ok := task.FieldValue(1)
+		if !ok || number.NumberValue == nil || *number.NumberValue != 0 || number.TaskID != id {
+			t.Errorf("expected task %d to keep its number set to 0, got %+v", id, task.FieldValues)
+		}
+	}
+	// Read back from the log, and then from a snapshot
+	repo = reopen(t, repo, dir)
+	check(ids[0])
+	check(ids[1])
+	if text, _ := repo.FindByID(ids[0]); len(text.FieldValues) != 2 || text.FieldValues[1].TextValue == nil {
+		t.Errorf("expected the empty text to be kept, got %+v", text.FieldValues)
+	}
+	if err := repo.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %v", err)
+	}
+	repo = reopen(t, repo, dir)
+	check(ids[0])
+	check(ids[1])
+}
@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryAttachmentRepository struct {
+	attachments map[uint]domain.Attachment
+	mutex       sync.Mutex
+	nextID      uint
+}
+
+func NewMockAttachmentRepository() *MemoryAttachmentRepository {
+	return &MemoryAttachmentRepository{
+		attachments: make(map[uint]domain.Attachment),
+		nextID:      1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryAttachmentRepository) Save(attachment domain.Attachment) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if attachment.ID == 0 {
+		attachment.ID = r.nextID
+		r.nextID++
+	}
+	r.attachments[attachment.ID] = attachment
+	return attachment.ID, nil
+}
+
+func (r *MemoryAttachmentRepository) FindByID(id uint) (domain.Attachment, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attachment, exists := r.attachments[id]
+	if !exists {
+		return attachment, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (r *MemoryAttachmentRepository) FindByTask(taskID uint) ([]domain.Attachment, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attachments := make([]domain.Attachment, 0)
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].ID < attachments[j].ID
+	})
+	return attachments, nil
+}
+
+func (r *MemoryAttachmentRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.attachments[id]
+	if !exists {
+		return domain.ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *MemoryAttachmentRepository) DeleteByTask(taskID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			delete(r.attachments, id)
+		}
+	}
+	return nil
+}
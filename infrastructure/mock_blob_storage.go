@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryBlobStorage struct {
+	blobs map[string][]byte
+	mutex sync.Mutex
+}
+
+func NewMockBlobStorage() *MemoryBlobStorage {
+	return &MemoryBlobStorage{blobs: make(map[string][]byte)}
+}
+
+func (s *MemoryBlobStorage) Put(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *MemoryBlobStorage) Open(key string) (io.ReadCloser, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, exists := s.blobs[key]
+	if !exists {
+		return nil, domain.ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryBlobStorage) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.blobs, key)
+	return nil
+}
@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryCommentRepository struct {
+	comments map[uint]domain.Comment
+	mutex    sync.Mutex
+	nextID   uint
+}
+
+func NewMockCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{
+		comments: make(map[uint]domain.Comment),
+		nextID:   1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryCommentRepository) Save(comment domain.Comment) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if comment.ID == 0 {
+		comment.ID = r.nextID
+		r.nextID++
+	}
+	r.comments[comment.ID] = comment
+	return comment.ID, nil
+}
+
+func (r *MemoryCommentRepository) FindByID(id uint) (domain.Comment, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	comment, exists := r.comments[id]
+	if !exists {
+		return comment, domain.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (r *MemoryCommentRepository) FindByTask(taskID uint) ([]domain.Comment, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	comments := make([]domain.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return comments, nil
+}
+
+func (r *MemoryCommentRepository) Update(comment domain.Comment) (domain.Comment, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingComment, exists := r.comments[comment.ID]
+	if !exists {
+		return existingComment, domain.ErrCommentNotFound
+	}
+	r.comments[comment.ID] = comment
+	return comment, nil
+}
+
+func (r *MemoryCommentRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.comments[id]
+	if !exists {
+		return domain.ErrCommentNotFound
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+func (r *MemoryCommentRepository) DeleteByTask(taskID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, comment := range r.comments {
+		if comment.TaskID == taskID {
+			delete(r.comments, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryCustomFieldRepository struct {
+	fields map[uint]domain.CustomField
+	tasks  *MemoryTaskRepository // Drops the values of deleted fields
+	mutex  sync.Mutex
+	nextID uint
+}
+
+func NewMockCustomFieldRepository(tasks *MemoryTaskRepository) *MemoryCustomFieldRepository {
+	return &MemoryCustomFieldRepository{
+		fields: make(map[uint]domain.CustomField),
+		tasks:  tasks,
+		nextID: 1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryCustomFieldRepository) Save(field domain.CustomField) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if field.ID == 0 {
+		field.ID = r.nextID
+		r.nextID++
+	}
+	field.Options = append([]string(nil), field.Options...)
+	r.fields[field.ID] = field
+	return field.ID, nil
+}
+
+func (r *MemoryCustomFieldRepository) FindByID(id uint) (domain.CustomField, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	field, exists := r.fields[id]
+	if !exists {
+		return field, domain.ErrCustomFieldNotFound
+	}
+	return field, nil
+}
+
+func (r *MemoryCustomFieldRepository) FindByProject(projectID uint) ([]domain.CustomField, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	fields := make([]domain.CustomField, 0)
+	for _, field := range r.fields {
+		if field.ProjectID == projectID {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].ID < fields[j].ID
+	})
+	return fields, nil
+}
+
+func (r *MemoryCustomFieldRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.fields[id]; !exists {
+		return domain.ErrCustomFieldNotFound
+	}
+	delete(r.fields, id)
+	if r.tasks != nil {
+		return r.tasks.removeFieldValues(id)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryProjectRepository struct {
+	projects map[uint]domain.Project
+	mutex    sync.Mutex
+	nextID   uint
+}
+
+func NewMockProjectRepository() *MemoryProjectRepository {
+	return &MemoryProjectRepository{
+		projects: make(map[uint]domain.Project),
+		nextID:   1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryProjectRepository) Save(project domain.Project) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if project.ID == 0 {
+		project.ID = r.nextID
+		r.nextID++
+	}
+	r.projects[project.ID] = project
+	return project.ID, nil
+}
+
+func (r *MemoryProjectRepository) FindByID(id uint) (domain.Project, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	project, exists := r.projects[id]
+	if !exists {
+		return project, domain.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (r *MemoryProjectRepository) FindAll() ([]domain.Project, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	projects := make([]domain.Project, 0, len(r.projects))
+	for _, project := range r.projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+	return projects, nil
+}
+
+func (r *MemoryProjectRepository) Update(project domain.Project) (domain.Project, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingProject, exists := r.projects[project.ID]
+	if !exists {
+		return existingProject, domain.ErrProjectNotFound
+	}
+	r.projects[project.ID] = project
+	return project, nil
+}
+
+func (r *MemoryProjectRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.projects[id]
+	if !exists {
+		return domain.ErrProjectNotFound
+	}
+	delete(r.projects, id)
+	return nil
+}
@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryReminderRepository struct {
+	reminders map[uint]domain.Reminder
+	mutex     sync.Mutex
+	nextID    uint
+}
+
+func NewMockReminderRepository() *MemoryReminderRepository {
+	return &MemoryReminderRepository{
+		reminders: make(map[uint]domain.Reminder),
+		nextID:    1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryReminderRepository) Save(reminder domain.Reminder) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if reminder.ID == 0 {
+		reminder.ID = r.nextID
+		r.nextID++
+	}
+	r.reminders[reminder.ID] = reminder
+	return reminder.ID, nil
+}
+
+func (r *MemoryReminderRepository) FindByID(id uint) (domain.Reminder, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reminder, exists := r.reminders[id]
+	if !exists {
+		return reminder, domain.ErrReminderNotFound
+	}
+	return reminder, nil
+}
+
+func (r *MemoryReminderRepository) FindByTask(taskID uint) ([]domain.Reminder, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reminders := make([]domain.Reminder, 0)
+	for _, reminder := range r.reminders {
+		if reminder.TaskID == taskID {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool {
+		return reminders[i].ID < reminders[j].ID
+	})
+	return reminders, nil
+}
+
+func (r *MemoryReminderRepository) FindDue(before time.Time) ([]domain.Reminder, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reminders := make([]domain.Reminder, 0)
+	for _, reminder := range r.reminders {
+		if reminder.Status == domain.ReminderPending && reminder.FireAt != nil && !reminder.FireAt.After(before) {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].FireAt.Equal(*reminders[j].FireAt) {
+			return reminders[i].FireAt.Before(*reminders[j].FireAt)
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+	return reminders, nil
+}
+
+func (r *MemoryReminderRepository) Update(reminder domain.Reminder) (domain.Reminder, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingReminder, exists := r.reminders[reminder.ID]
+	if !exists {
+		return existingReminder, domain.ErrReminderNotFound
+	}
+	r.reminders[reminder.ID] = reminder
+	return reminder, nil
+}
+
+func (r *MemoryReminderRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.reminders[id]
+	if !exists {
+		return domain.ErrReminderNotFound
+	}
+	delete(r.reminders, id)
+	return nil
+}
+
+func (r *MemoryReminderRepository) DeleteByTask(taskID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, reminder := range r.reminders {
+		if reminder.TaskID == taskID {
+			delete(r.reminders, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryRevisionRepository struct {
+	revisions map[uint]domain.Revision
+	mutex     sync.Mutex
+	nextID    uint
+}
+
+func NewMockRevisionRepository() *MemoryRevisionRepository {
+	return &MemoryRevisionRepository{
+		revisions: make(map[uint]domain.Revision),
+		nextID:    1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryRevisionRepository) Save(revision domain.Revision) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	revision.ID = r.nextID
+	r.nextID++
+	revision.Changes = append([]domain.FieldChange(nil), revision.Changes...)
+	r.revisions[revision.ID] = revision
+	return revision.ID, nil
+}
+
+func (r *MemoryRevisionRepository) FindByTask(taskID uint) ([]domain.Revision, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	revisions := make([]domain.Revision, 0)
+	for _, revision := range r.revisions {
+		if revision.TaskID == taskID {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].ID < revisions[j].ID
+	})
+	return revisions, nil
+}
+
+func (r *MemoryRevisionRepository) DeleteByTask(taskID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, revision := range r.revisions {
+		if revision.TaskID == taskID {
+			delete(r.revisions, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// MemoryTagRepository keeps tags in memory. Tags are embedded in the tasks of
+// the linked MemoryTaskRepository, so renames and deletes are mirrored there.
+type MemoryTagRepository struct {
+	tags   map[uint]domain.Tag
+	tasks  *MemoryTaskRepository
+	mutex  sync.Mutex
+	nextID uint
+}
+
+func NewMockTagRepository(tasks *MemoryTaskRepository) *MemoryTagRepository {
+	return &MemoryTagRepository{
+		tags:   make(map[uint]domain.Tag),
+		tasks:  tasks,
+		nextID: 1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryTagRepository) Save(tag domain.Tag) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if tag.ID == 0 {
+		tag.ID = r.nextID
+		r.nextID++
+	}
+	r.tags[tag.ID] = tag
+	return tag.ID, nil
+}
+
+func (r *MemoryTagRepository) FindByID(id uint) (domain.Tag, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return tag, domain.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (r *MemoryTagRepository) FindByName(name string) (domain.Tag, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, tag := range r.tags {
+		if tag.Name == name {
+			return tag, nil
+		}
+	}
+	return domain.Tag{}, domain.ErrTagNotFound
+}
+
+func (r *MemoryTagRepository) FindAll() ([]domain.Tag, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tags := make([]domain.Tag, 0, len(r.tags))
+	for _, tag := range r.tags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+func (r *MemoryTagRepository) Update(tag domain.Tag) (domain.Tag, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingTag, exists := r.tags[tag.ID]
+	if !exists {
+		return existingTag, domain.ErrTagNotFound
+	}
+	r.tags[tag.ID] = tag
+	if r.tasks != nil {
+		if err := r.tasks.replaceTag(tag, true); err != nil {
+			return domain.Tag{}, err
+		}
+	}
+	return tag, nil
+}
+
+func (r *MemoryTagRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return domain.ErrTagNotFound
+	}
+	delete(r.tags, id)
+	if r.tasks != nil {
+		if err := r.tasks.replaceTag(tag, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
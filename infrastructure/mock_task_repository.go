@@ -1,34 +1,86 @@
 package infrastructure
 
 import (
-	"errors"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/krishnakumarkp/to-do/domain"
 )
 
 type MemoryTaskRepository struct {
-	tasks  map[uint]domain.Task
-	mutex  sync.Mutex
-	nextID uint
+	tasks      map[uint]domain.Task
+	trashed    map[uint]domain.Task   // Tasks in the trash, kept apart so no other query sees them
+	blockers   map[uint]map[uint]bool // task ID to the IDs of the tasks blocking it
+	index      *searchIndex           // Words of the tasks outside the trash, for Search
+	mutex      sync.Mutex
+	nextID     uint
+	nextItemID uint
+	log        *taskLog // Where changes are written before being applied; nil keeps them in memory only
 }
 
 func NewMockTaskRepository() *MemoryTaskRepository {
 	return &MemoryTaskRepository{
-		tasks:  make(map[uint]domain.Task),
-		nextID: 1, // Start IDs from 1
+		tasks:      make(map[uint]domain.Task),
+		trashed:    make(map[uint]domain.Task),
+		blockers:   make(map[uint]map[uint]bool),
+		index:      newSearchIndex(),
+		nextID:     1, // Start IDs from 1
+		nextItemID: 1,
 	}
 }
 
+// cloneTask copies the task's slices so stored tasks never share memory with callers
+func cloneTask(task domain.Task) domain.Task {
+	if task.Tags != nil {
+		task.Tags = append([]domain.Tag(nil), task.Tags...)
+	}
+	if task.Checklist != nil {
+		task.Checklist = append([]domain.ChecklistItem(nil), task.Checklist...)
+	}
+	if task.FieldValues != nil {
+		task.FieldValues = append([]domain.FieldValue(nil), task.FieldValues...)
+	}
+	return task
+}
+
+// assignItemIDs links the task's checklist items and field values to it and
+// numbers new checklist items, returning the next free item ID
+func (r *MemoryTaskRepository) assignItemIDs(task *domain.Task) uint {
+	next := r.nextItemID
+	for i := range task.FieldValues {
+		task.FieldValues[i].TaskID = task.ID
+	}
+	for i := range task.Checklist {
+		task.Checklist[i].TaskID = task.ID
+		if task.Checklist[i].ID == 0 {
+			task.Checklist[i].ID = next
+			next++
+		}
+	}
+	return next
+}
+
 func (r *MemoryTaskRepository) Save(task domain.Task) (uint, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	change := taskChange{NextID: r.nextID}
 	if task.ID == 0 {
 		task.ID = r.nextID
-		r.nextID++
+		change.NextID++
+	}
+	if task.Position == 0 {
+		task.Position = r.lastPosition() + domain.PositionGap
+	}
+	task = cloneTask(task)
+	change.NextItemID = r.assignItemIDs(&task)
+	change.Put = []storedTask{{Task: task}}
+	if err := r.apply(change); err != nil {
+		return 0, err
 	}
-	r.tasks[task.ID] = task
 	return task.ID, nil
 }
 
@@ -38,9 +90,9 @@ func (r *MemoryTaskRepository) FindByID(id uint) (domain.Task, error) {
 
 	task, exists := r.tasks[id]
 	if !exists {
-		return task, errors.New("task not found")
+		return task, domain.ErrTaskNotFound
 	}
-	return task, nil
+	return cloneTask(task), nil
 }
 
 func (r *MemoryTaskRepository) FindAll() ([]domain.Task, error) {
@@ -49,31 +101,547 @@ func (r *MemoryTaskRepository) FindAll() ([]domain.Task, error) {
 
 	tasks := make([]domain.Task, 0, len(r.tasks))
 	for _, task := range r.tasks {
+		if task.ArchivedAt == nil {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) Query(query domain.TaskQuery) (domain.TaskPage, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt != nil || !query.Matches(task) {
+			continue
+		}
+		if query.After != nil && query.Compare(task, query.After.Task()) <= 0 {
+			continue
+		}
 		tasks = append(tasks, task)
 	}
+	slices.SortFunc(tasks, query.Compare)
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		next := domain.CursorAt(page.Tasks[query.Limit-1])
+		page.Next = &next
+	}
+	for i, task := range page.Tasks {
+		page.Tasks[i] = cloneTask(task)
+	}
+	return page, nil
+}
+
+func (r *MemoryTaskRepository) Search(terms []string, limit int) ([]domain.SearchHit, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ids, scores := r.index.search(terms)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	hits := make([]domain.SearchHit, 0, len(ids))
+	for _, id := range ids {
+		hits = append(hits, domain.SearchHit{Task: cloneTask(r.tasks[id]), Score: scores[id]})
+	}
+	return hits, nil
+}
+
+func (r *MemoryTaskRepository) FindByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt != nil {
+			continue
+		}
+		matched := 0
+		for _, name := range names {
+			for _, tag := range task.Tags {
+				if tag.Name == name {
+					matched++
+					break
+				}
+			}
+		}
+		if (matchAll && matched == len(names)) || (!matchAll && matched > 0) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindOpenDueBefore(before time.Time) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if !task.Completed && task.DueDate != nil && !task.DueDate.After(before) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindChildren(parentID uint) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ParentID != nil && *task.ParentID == parentID {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
 	return tasks, nil
 }
 
+func (r *MemoryTaskRepository) FindSeries(seriesID uint) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.SeriesRoot() == seriesID {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindByProject(projectID uint) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt == nil && task.ProjectID != nil && *task.ProjectID == projectID {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) CountByProject() (map[uint]domain.TaskCounts, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	counts := make(map[uint]domain.TaskCounts)
+	for _, task := range r.tasks {
+		if task.ProjectID == nil {
+			continue
+		}
+		c := counts[*task.ProjectID]
+		c.Total++
+		if !task.Completed {
+			c.Open++
+		}
+		counts[*task.ProjectID] = c
+	}
+	return counts, nil
+}
+
+func (r *MemoryTaskRepository) FindBlockers(taskID uint) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0, len(r.blockers[taskID]))
+	for id := range r.blockers[taskID] {
+		if task, exists := r.tasks[id]; exists {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindReady() ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.Completed {
+			continue
+		}
+		ready := true
+		for id := range r.blockers[task.ID] {
+			if blocker, exists := r.tasks[id]; exists && !blocker.Completed {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindByAssignee(userID uint) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt == nil && task.AssigneeID != nil && *task.AssigneeID == userID {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindUnassigned() ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt == nil && task.AssigneeID == nil {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindByFieldValues(values []domain.FieldValue) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt != nil {
+			continue
+		}
+		matched := true
+		for _, value := range values {
+			if held, ok := task.FieldValue(value.FieldID); !ok || !held.Equal(value) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) AddDependency(dependency domain.TaskDependency) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.apply(taskChange{Block: []domain.TaskDependency{dependency}})
+}
+
+func (r *MemoryTaskRepository) RemoveDependency(taskID, blockerID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.blockers[taskID][blockerID] {
+		return domain.ErrDependencyNotFound
+	}
+	return r.apply(taskChange{Unblock: []domain.TaskDependency{{TaskID: taskID, BlockerID: blockerID}}})
+}
+
 func (r *MemoryTaskRepository) Update(task domain.Task) (domain.Task, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
 	existingTask, exists := r.tasks[task.ID]
 	if !exists {
-		return existingTask, errors.New("task not found")
+		if existingTask, exists = r.trashed[task.ID]; !exists {
+			return existingTask, domain.ErrTaskNotFound
+		}
+	}
+	task = cloneTask(task)
+	task.Position = existingTask.Position
+	task.DeletedAt = existingTask.DeletedAt
+	next := r.assignItemIDs(&task)
+	if err := r.apply(taskChange{Put: []storedTask{r.stored(task)}, NextItemID: next}); err != nil {
+		return domain.Task{}, err
 	}
-	r.tasks[task.ID] = task
-	return task, nil
+	return cloneTask(task), nil
 }
 
-func (r *MemoryTaskRepository) Delete(id uint) error {
+func (r *MemoryTaskRepository) Move(id, anchorID uint, after bool) (domain.Task, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
 	_, exists := r.tasks[id]
+	if _, anchored := r.tasks[anchorID]; !exists || !anchored || id == anchorID {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	tasks := r.ordered()
+	position, ok := positionAmong(tasks, id, anchorID, after)
+	var change taskChange
+	if !ok {
+		change.Put = r.respace(tasks)
+		position, _ = positionAmong(tasks, id, anchorID, after)
+	}
+	task := r.tasks[id]
+	task.Position = position
+	change.Put = append(change.Put, storedTask{Task: task})
+	if err := r.apply(change); err != nil {
+		return domain.Task{}, err
+	}
+	return cloneTask(task), nil
+}
+
+// ordered returns every stored task, trashed ones included, in manual order
+func (r *MemoryTaskRepository) ordered() []domain.Task {
+	tasks := make([]domain.Task, 0, len(r.tasks)+len(r.trashed))
+	for _, stored := range []map[uint]domain.Task{r.tasks, r.trashed} {
+		for _, task := range stored {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return domain.LessByPosition(tasks[i], tasks[j])
+	})
+	return tasks
+}
+
+// lastPosition returns the highest position in use, or zero when there are no tasks
+func (r *MemoryTaskRepository) lastPosition() int64 {
+	if tasks := r.ordered(); len(tasks) > 0 {
+		return tasks[len(tasks)-1].Position
+	}
+	return 0
+}
+
+// positionAmong finds a free position among the ordered tasks directly
+// before or after the anchor, not counting the moved task
+func positionAmong(ordered []domain.Task, id, anchorID uint, after bool) (int64, bool) {
+	tasks := make([]domain.Task, 0, len(ordered))
+	for _, task := range ordered {
+		if task.ID != id {
+			tasks = append(tasks, task)
+		}
+	}
+	for i, task := range tasks {
+		if task.ID != anchorID {
+			continue
+		}
+		var neighbour *int64
+		if after && i+1 < len(tasks) {
+			neighbour = &tasks[i+1].Position
+		}
+		if !after && i > 0 {
+			neighbour = &tasks[i-1].Position
+		}
+		if after {
+			return domain.PositionBetween(&task.Position, neighbour)
+		}
+		return domain.PositionBetween(neighbour, &task.Position)
+	}
+	return 0, false
+}
+
+// respace spreads the ordered tasks PositionGap apart, keeping their order,
+// and returns them to be stored
+func (r *MemoryTaskRepository) respace(ordered []domain.Task) []storedTask {
+	respaced := make([]storedTask, len(ordered))
+	for i := range ordered {
+		ordered[i].Position = int64(i+1) * domain.PositionGap
+		respaced[i] = r.stored(ordered[i])
+	}
+	return respaced
+}
+
+// stored wraps a task to be put back where it is kept now, in the trash or not
+func (r *MemoryTaskRepository) stored(task domain.Task) storedTask {
+	_, trashed := r.trashed[task.ID]
+	return storedTask{Task: task, Trashed: trashed}
+}
+
+func (r *MemoryTaskRepository) FindArchived(query string) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	query = strings.ToLower(query)
+	tasks := make([]domain.Task, 0)
+	for _, task := range r.tasks {
+		if task.ArchivedAt == nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(task.Title), query) || strings.Contains(strings.ToLower(task.Description), query) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].ArchivedAt.Equal(*tasks[j].ArchivedAt) {
+			return tasks[i].ArchivedAt.After(*tasks[j].ArchivedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) ArchiveCompletedBefore(before, at time.Time) ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var change taskChange
+	var archived []domain.Task
+	for _, task := range r.tasks {
+		if task.Completed && task.ArchivedAt == nil && task.CompletedAt != nil && task.CompletedAt.Before(before) {
+			task.ArchivedAt = &at
+			change.Put = append(change.Put, storedTask{Task: task})
+			archived = append(archived, cloneTask(task))
+		}
+	}
+	if err := r.apply(change); err != nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+func (r *MemoryTaskRepository) Trash(id uint, at time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	task, exists := r.tasks[id]
+	if !exists {
+		return domain.ErrTaskNotFound
+	}
+	task.DeletedAt = &at
+	return r.apply(taskChange{Put: []storedTask{{Task: task, Trashed: true}}})
+}
+
+func (r *MemoryTaskRepository) Restore(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	task, exists := r.trashed[id]
 	if !exists {
-		return errors.New("task not found")
+		return domain.ErrTaskNotFound
+	}
+	task.DeletedAt = nil
+	return r.apply(taskChange{Put: []storedTask{{Task: task}}})
+}
+
+func (r *MemoryTaskRepository) FindTrashed() ([]domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tasks := make([]domain.Task, 0, len(r.trashed))
+	for _, task := range r.trashed {
+		tasks = append(tasks, cloneTask(task))
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DeletedAt.Equal(*tasks[j].DeletedAt) {
+			return tasks[i].DeletedAt.After(*tasks[j].DeletedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindTrashedByID(id uint) (domain.Task, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	task, exists := r.trashed[id]
+	if !exists {
+		return task, domain.ErrTaskNotFound
+	}
+	return cloneTask(task), nil
+}
+
+func (r *MemoryTaskRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, live := r.tasks[id]
+	_, trashed := r.trashed[id]
+	if !live && !trashed {
+		return domain.ErrTaskNotFound
+	}
+	return r.apply(taskChange{Delete: []uint{id}})
+}
+
+// replaceTag rewrites (or, when keep is false, removes) the given tag on every task carrying it, trashed or not
+func (r *MemoryTaskRepository) replaceTag(tag domain.Tag, keep bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var change taskChange
+	for _, tasks := range []map[uint]domain.Task{r.tasks, r.trashed} {
+		for _, task := range tasks {
+			if !task.HasTag(tag.ID) {
+				continue
+			}
+			tags := make([]domain.Tag, 0, len(task.Tags))
+			for _, t := range task.Tags {
+				switch {
+				case t.ID != tag.ID:
+					tags = append(tags, t)
+				case keep:
+					tags = append(tags, tag)
+				}
+			}
+			task.Tags = tags
+			change.Put = append(change.Put, r.stored(task))
+		}
+	}
+	return r.apply(change)
+}
+
+// removeFieldValues drops every task's value for the given custom field, trashed or not
+func (r *MemoryTaskRepository) removeFieldValues(fieldID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var change taskChange
+	for _, tasks := range []map[uint]domain.Task{r.tasks, r.trashed} {
+		for _, task := range tasks {
+			values := make([]domain.FieldValue, 0, len(task.FieldValues))
+			for _, value := range task.FieldValues {
+				if value.FieldID != fieldID {
+					values = append(values, value)
+				}
+			}
+			if len(values) != len(task.FieldValues) {
+				task.FieldValues = values
+				change.Put = append(change.Put, r.stored(task))
+			}
+		}
 	}
-	delete(r.tasks, id)
-	return nil
+	return r.apply(change)
 }
@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryTimeEntryRepository struct {
+	entries map[uint]domain.TimeEntry
+	tasks   *MemoryTaskRepository // Resolves which tasks belong to a project
+	mutex   sync.Mutex
+	nextID  uint
+}
+
+func NewMockTimeEntryRepository(tasks *MemoryTaskRepository) *MemoryTimeEntryRepository {
+	return &MemoryTimeEntryRepository{
+		entries: make(map[uint]domain.TimeEntry),
+		tasks:   tasks,
+		nextID:  1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryTimeEntryRepository) Save(entry domain.TimeEntry) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.save(entry), nil
+}
+
+// save stores the entry, numbering it when new; the caller holds the lock
+func (r *MemoryTimeEntryRepository) save(entry domain.TimeEntry) uint {
+	if entry.ID == 0 {
+		entry.ID = r.nextID
+		r.nextID++
+	}
+	r.entries[entry.ID] = entry
+	return entry.ID
+}
+
+func (r *MemoryTimeEntryRepository) Start(entry domain.TimeEntry) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if entry.UserID != nil {
+		for _, e := range r.entries {
+			if e.Running() && e.UserID != nil && *e.UserID == *entry.UserID {
+				return 0, domain.ErrTimerRunning
+			}
+		}
+	}
+	return r.save(entry), nil
+}
+
+func (r *MemoryTimeEntryRepository) FindByID(id uint) (domain.TimeEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, exists := r.entries[id]
+	if !exists {
+		return entry, domain.ErrTimeEntryNotFound
+	}
+	return entry, nil
+}
+
+func (r *MemoryTimeEntryRepository) FindByTask(taskID uint) ([]domain.TimeEntry, error) {
+	return r.find(map[uint]bool{taskID: true}), nil
+}
+
+func (r *MemoryTimeEntryRepository) FindByProject(projectID uint) ([]domain.TimeEntry, error) {
+	tasks, err := r.tasks.FindByProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint]bool, len(tasks))
+	for _, task := range tasks {
+		ids[task.ID] = true
+	}
+	return r.find(ids), nil
+}
+
+// find returns the entries of the given tasks, oldest first
+func (r *MemoryTimeEntryRepository) find(taskIDs map[uint]bool) []domain.TimeEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries := make([]domain.TimeEntry, 0)
+	for _, entry := range r.entries {
+		if taskIDs[entry.TaskID] {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartedAt.Equal(entries[j].StartedAt) {
+			return entries[i].StartedAt.Before(entries[j].StartedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+func (r *MemoryTimeEntryRepository) FindRunning(userID uint) (domain.TimeEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.Running() && entry.UserID != nil && *entry.UserID == userID {
+			return entry, nil
+		}
+	}
+	return domain.TimeEntry{}, domain.ErrTimeEntryNotFound
+}
+
+func (r *MemoryTimeEntryRepository) Update(entry domain.TimeEntry) (domain.TimeEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingEntry, exists := r.entries[entry.ID]
+	if !exists {
+		return existingEntry, domain.ErrTimeEntryNotFound
+	}
+	r.entries[entry.ID] = entry
+	return entry, nil
+}
+
+func (r *MemoryTimeEntryRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.entries[id]
+	if !exists {
+		return domain.ErrTimeEntryNotFound
+	}
+	delete(r.entries, id)
+	return nil
+}
+
+func (r *MemoryTimeEntryRepository) DeleteByTask(taskID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, entry := range r.entries {
+		if entry.TaskID == taskID {
+			delete(r.entries, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+type MemoryUserRepository struct {
+	users  map[uint]domain.User
+	mutex  sync.Mutex
+	nextID uint
+}
+
+func NewMockUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users:  make(map[uint]domain.User),
+		nextID: 1, // Start IDs from 1
+	}
+}
+
+func (r *MemoryUserRepository) Save(user domain.User) (uint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if user.ID == 0 {
+		user.ID = r.nextID
+		r.nextID++
+	}
+	r.users[user.ID] = user
+	return user.ID, nil
+}
+
+func (r *MemoryUserRepository) FindByID(id uint) (domain.User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		return user, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *MemoryUserRepository) FindByEmail(email string) (domain.User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return domain.User{}, domain.ErrUserNotFound
+}
+
+func (r *MemoryUserRepository) FindAll() ([]domain.User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *MemoryUserRepository) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.users[id]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLAttachmentRepository(db *gorm.DB) *MySQLAttachmentRepository {
+	return &MySQLAttachmentRepository{db: db}
+}
+
+func (r *MySQLAttachmentRepository) Save(attachment domain.Attachment) (uint, error) {
+	result := r.db.Create(&attachment)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return attachment.ID, nil
+}
+
+func (r *MySQLAttachmentRepository) FindByID(id uint) (domain.Attachment, error) {
+	var attachment domain.Attachment
+	result := r.db.First(&attachment, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return attachment, domain.ErrAttachmentNotFound
+	}
+	return attachment, result.Error
+}
+
+// FindByTask returns a task's attachments in upload order
+func (r *MySQLAttachmentRepository) FindByTask(taskID uint) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	result := r.db.Where("task_id = ?", taskID).Order("id").Find(&attachments)
+	return attachments, result.Error
+}
+
+func (r *MySQLAttachmentRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Attachment{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return result.Error
+}
+
+// DeleteByTask removes every attachment of a task
+func (r *MySQLAttachmentRepository) DeleteByTask(taskID uint) error {
+	return r.db.Where("task_id = ?", taskID).Delete(&domain.Attachment{}).Error
+}
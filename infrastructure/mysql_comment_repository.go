@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLCommentRepository(db *gorm.DB) *MySQLCommentRepository {
+	return &MySQLCommentRepository{db: db}
+}
+
+func (r *MySQLCommentRepository) Save(comment domain.Comment) (uint, error) {
+	result := r.db.Create(&comment)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return comment.ID, nil
+}
+
+func (r *MySQLCommentRepository) FindByID(id uint) (domain.Comment, error) {
+	var comment domain.Comment
+	result := r.db.First(&comment, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return comment, domain.ErrCommentNotFound
+	}
+	return comment, result.Error
+}
+
+// FindByTask returns a task's comments, oldest first
+func (r *MySQLCommentRepository) FindByTask(taskID uint) ([]domain.Comment, error) {
+	var comments []domain.Comment
+	result := r.db.Where("task_id = ?", taskID).Order("created_at").Order("id").Find(&comments)
+	return comments, result.Error
+}
+
+func (r *MySQLCommentRepository) Update(comment domain.Comment) (domain.Comment, error) {
+	if err := r.db.Save(&comment).Error; err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (r *MySQLCommentRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Comment{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return result.Error
+}
+
+// DeleteByTask removes every comment of a task
+func (r *MySQLCommentRepository) DeleteByTask(taskID uint) error {
+	return r.db.Where("task_id = ?", taskID).Delete(&domain.Comment{}).Error
+}
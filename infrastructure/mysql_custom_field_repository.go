@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLCustomFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLCustomFieldRepository(db *gorm.DB) *MySQLCustomFieldRepository {
+	return &MySQLCustomFieldRepository{db: db}
+}
+
+func (r *MySQLCustomFieldRepository) Save(field domain.CustomField) (uint, error) {
+	result := r.db.Create(&field)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return 0, fmt.Errorf("custom field %q %w", field.Name, domain.ErrDuplicate)
+	}
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return field.ID, nil
+}
+
+func (r *MySQLCustomFieldRepository) FindByID(id uint) (domain.CustomField, error) {
+	var field domain.CustomField
+	result := r.db.First(&field, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return field, domain.ErrCustomFieldNotFound
+	}
+	return field, result.Error
+}
+
+// FindByProject returns the custom fields a project defines, in the order they were added
+func (r *MySQLCustomFieldRepository) FindByProject(projectID uint) ([]domain.CustomField, error) {
+	var fields []domain.CustomField
+	result := r.db.Where("project_id = ?", projectID).Order("id").Find(&fields)
+	return fields, result.Error
+}
+
+// Delete removes a custom field and every task's value for it
+func (r *MySQLCustomFieldRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", id).Delete(&domain.FieldValue{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.CustomField{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrCustomFieldNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLProjectRepository(db *gorm.DB) *MySQLProjectRepository {
+	return &MySQLProjectRepository{db: db}
+}
+
+func (r *MySQLProjectRepository) Save(project domain.Project) (uint, error) {
+	result := r.db.Create(&project)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return project.ID, nil
+}
+
+func (r *MySQLProjectRepository) FindByID(id uint) (domain.Project, error) {
+	var project domain.Project
+	result := r.db.First(&project, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return project, domain.ErrProjectNotFound
+	}
+	return project, result.Error
+}
+
+func (r *MySQLProjectRepository) FindAll() ([]domain.Project, error) {
+	var projects []domain.Project
+	result := r.db.Order("id").Find(&projects)
+	return projects, result.Error
+}
+
+func (r *MySQLProjectRepository) Update(project domain.Project) (domain.Project, error) {
+	if err := r.db.Save(&project).Error; err != nil {
+		return domain.Project{}, err
+	}
+	return project, nil
+}
+
+func (r *MySQLProjectRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Project{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrProjectNotFound
+	}
+	return result.Error
+}
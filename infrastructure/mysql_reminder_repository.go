@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"errors"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLReminderRepository(db *gorm.DB) *MySQLReminderRepository {
+	return &MySQLReminderRepository{db: db}
+}
+
+func (r *MySQLReminderRepository) Save(reminder domain.Reminder) (uint, error) {
+	result := r.db.Create(&reminder)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return reminder.ID, nil
+}
+
+func (r *MySQLReminderRepository) FindByID(id uint) (domain.Reminder, error) {
+	var reminder domain.Reminder
+	result := r.db.First(&reminder, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return reminder, domain.ErrReminderNotFound
+	}
+	return reminder, result.Error
+}
+
+// FindByTask returns a task's reminders in creation order
+func (r *MySQLReminderRepository) FindByTask(taskID uint) ([]domain.Reminder, error) {
+	var reminders []domain.Reminder
+	result := r.db.Where("task_id = ?", taskID).Order("id").Find(&reminders)
+	return reminders, result.Error
+}
+
+// FindDue returns pending reminders whose fire time has come, earliest first
+func (r *MySQLReminderRepository) FindDue(before time.Time) ([]domain.Reminder, error) {
+	var reminders []domain.Reminder
+	result := r.db.Where("status = ? AND fire_at <= ?", domain.ReminderPending, before).
+		Order("fire_at").
+		Order("id").
+		Find(&reminders)
+	return reminders, result.Error
+}
+
+func (r *MySQLReminderRepository) Update(reminder domain.Reminder) (domain.Reminder, error) {
+	if err := r.db.Save(&reminder).Error; err != nil {
+		return domain.Reminder{}, err
+	}
+	return reminder, nil
+}
+
+func (r *MySQLReminderRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Reminder{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrReminderNotFound
+	}
+	return result.Error
+}
+
+// DeleteByTask removes every reminder of a task
+func (r *MySQLReminderRepository) DeleteByTask(taskID uint) error {
+	return r.db.Where("task_id = ?", taskID).Delete(&domain.Reminder{}).Error
+}
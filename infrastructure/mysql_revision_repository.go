@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLRevisionRepository(db *gorm.DB) *MySQLRevisionRepository {
+	return &MySQLRevisionRepository{db: db}
+}
+
+func (r *MySQLRevisionRepository) Save(revision domain.Revision) (uint, error) {
+	result := r.db.Create(&revision)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return revision.ID, nil
+}
+
+// FindByTask returns a task's revisions, oldest first
+func (r *MySQLRevisionRepository) FindByTask(taskID uint) ([]domain.Revision, error) {
+	var revisions []domain.Revision
+	result := r.db.Where("task_id = ?", taskID).Order("id").Find(&revisions)
+	return revisions, result.Error
+}
+
+// DeleteByTask removes the whole history of a task
+func (r *MySQLRevisionRepository) DeleteByTask(taskID uint) error {
+	return r.db.Where("task_id = ?", taskID).Delete(&domain.Revision{}).Error
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLTagRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLTagRepository(db *gorm.DB) *MySQLTagRepository {
+	return &MySQLTagRepository{db: db}
+}
+
+func (r *MySQLTagRepository) Save(tag domain.Tag) (uint, error) {
+	result := r.db.Create(&tag)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return 0, fmt.Errorf("tag %q %w", tag.Name, domain.ErrDuplicate)
+	}
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return tag.ID, nil
+}
+
+func (r *MySQLTagRepository) FindByID(id uint) (domain.Tag, error) {
+	var tag domain.Tag
+	result := r.db.First(&tag, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return tag, domain.ErrTagNotFound
+	}
+	return tag, result.Error
+}
+
+func (r *MySQLTagRepository) FindByName(name string) (domain.Tag, error) {
+	var tag domain.Tag
+	result := r.db.Where("name = ?", name).First(&tag)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return tag, domain.ErrTagNotFound
+	}
+	return tag, result.Error
+}
+
+func (r *MySQLTagRepository) FindAll() ([]domain.Tag, error) {
+	var tags []domain.Tag
+	result := r.db.Order("name").Find(&tags)
+	return tags, result.Error
+}
+
+func (r *MySQLTagRepository) Update(tag domain.Tag) (domain.Tag, error) {
+	err := r.db.Save(&tag).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.Tag{}, fmt.Errorf("tag %q %w", tag.Name, domain.ErrDuplicate)
+	}
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	return tag, nil
+}
+
+// Delete removes the tag and detaches it from every task carrying it
+func (r *MySQLTagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Tag{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrTagNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSaveTag_DuplicateName(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock, translating errors as ConnectToDB does
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTagRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("^INSERT INTO `tags`").
+		WillReturnError(&mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry 'home' for key 'tags.idx_tags_name'"})
+	mock.ExpectRollback()
+
+	_, err = repo.Save(domain.Tag{Name: "home"})
+	if !errors.Is(err, domain.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate, got: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
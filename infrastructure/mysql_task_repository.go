@@ -1,57 +1,52 @@
 package infrastructure
 
 import (
-	"errors"
+	"strings"
 
 	"github.com/krishnakumarkp/to-do/domain"
 
 	"gorm.io/gorm"
 )
 
+// MySQLTaskRepository stores tasks in a MySQL database
 type MySQLTaskRepository struct {
-	db *gorm.DB
+	*gormTaskRepository
 }
 
 func NewMySQLTaskRepository(db *gorm.DB) *MySQLTaskRepository {
-	return &MySQLTaskRepository{db: db}
+	return &MySQLTaskRepository{&gormTaskRepository{db: db, dialect: mysqlTaskDialect{}}}
 }
 
-func (r *MySQLTaskRepository) Save(task domain.Task) (uint, error) {
-	result := r.db.Create(&task)
-	if result.Error != nil {
-		return 0, result.Error
-	}
-	return task.ID, nil
-}
-
-func (r *MySQLTaskRepository) FindByID(id uint) (domain.Task, error) {
-	var task domain.Task
-	result := r.db.First(&task, id)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return task, errors.New("task not found")
-	}
-	return task, result.Error
-}
+// mysqlTaskDialect relies on MySQL's case-insensitive default collation and
+// its FULLTEXT indexes
+type mysqlTaskDialect struct{}
 
-func (r *MySQLTaskRepository) FindAll() ([]domain.Task, error) {
-	var tasks []domain.Task
-	result := r.db.Find(&tasks)
-	return tasks, result.Error
+func (mysqlTaskDialect) sortColumn(sort domain.TaskSort) string {
+	return taskSortColumns[sort]
 }
 
-// UpdateTask updates a task in the database
-func (r *MySQLTaskRepository) Update(task domain.Task) (domain.Task, error) {
-	// Use GORM's Save method to update the task in the database
-	if err := r.db.Save(&task).Error; err != nil {
-		return domain.Task{}, err
-	}
-	return task, nil
+func (mysqlTaskDialect) like() string {
+	return "LIKE"
 }
 
-func (r *MySQLTaskRepository) Delete(id uint) error {
-	result := r.db.Delete(&domain.Task{}, id)
-	if result.RowsAffected == 0 {
-		return errors.New("task not found")
+// rank ranks tasks with the FULLTEXT indexes made by CreateSearchIndexes,
+// counting title matches twice. Every term must match and matches words it
+// is a prefix of. InnoDB does not index words shorter than
+// innodb_ft_min_token_size (3 by default) or stopwords, so such terms find nothing.
+func (mysqlTaskDialect) rank(db *gorm.DB, terms []string, limit int) ([]rankedTask, error) {
+	required := make([]string, len(terms))
+	for i, term := range terms {
+		required[i] = "+" + term + "*"
 	}
-	return result.Error
+	against := strings.Join(required, " ")
+
+	var ranked []rankedTask
+	result := db.Model(&domain.Task{}).
+		Select("tasks.id, MATCH(tasks.title) AGAINST (? IN BOOLEAN MODE) * 2 + MATCH(tasks.title, tasks.description) AGAINST (? IN BOOLEAN MODE) AS score", against, against).
+		Where("tasks.deleted_at IS NULL").
+		Where("MATCH(tasks.title, tasks.description) AGAINST (? IN BOOLEAN MODE)", against).
+		Order("score DESC").Order("tasks.id").
+		Limit(limit).
+		Scan(&ranked)
+	return ranked, result.Error
 }
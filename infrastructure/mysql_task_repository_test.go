@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -43,7 +44,9 @@ func TestSave(t *testing.T) {
 
 	// Set up expectations
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `tasks`").WithArgs(task.Title, task.Description, task.Completed, task.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("^SELECT COALESCE\\(MAX\\(position\\), 0\\) FROM `tasks`$").
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(3 * domain.PositionGap))
+	mock.ExpectExec("INSERT INTO `tasks`").WithArgs(task.Title, task.Description, task.Completed, task.CreatedAt, task.DueDate, task.DueHasTime, task.DueTimezone, task.Priority, task.ParentID, task.Recurrence, task.SeriesID, task.ProjectID, domain.StatusTodo, task.CompletedAt, task.AssigneeID, task.CreatorID, task.Estimate, task.DeletedAt, task.ArchivedAt, 4*domain.PositionGap).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	// Execute the function
@@ -100,19 +103,27 @@ func TestFindByID(t *testing.T) {
 				// Mock the SQL query
 				rows := sqlmock.NewRows([]string{"id", "title", "description"}).
 					AddRow(1, "Test Task", "Test description")
-				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
+				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
 					WithArgs(1, 1).
 					WillReturnRows(rows)
+				// Tags are preloaded through the join table
+				mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+				mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+				mock.ExpectQuery("^SELECT \\* FROM `task_tags` WHERE `task_tags`.`task_id` = \\?$").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
 			},
 			expectedErr: nil,
-			expectedRes: domain.Task{ID: 1, Title: "Test Task", Description: "Test description"},
+			expectedRes: domain.Task{ID: 1, Title: "Test Task", Description: "Test description", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}},
 		},
 		{
 			name:   "Task Not Found",
 			taskID: 2,
 			mockSetup: func() {
 				// Mock the SQL query to return no rows
-				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
+				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
 					WithArgs(2, 1).
 					WillReturnError(gorm.ErrRecordNotFound)
 			},
@@ -124,7 +135,7 @@ func TestFindByID(t *testing.T) {
 			taskID: 3,
 			mockSetup: func() {
 				// Mock the SQL query to simulate a database error
-				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
+				mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND `tasks`.`id` = \\? ORDER BY `tasks`.`id` LIMIT \\?$").
 					WithArgs(3, 1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -145,7 +156,7 @@ func TestFindByID(t *testing.T) {
 			if err != nil && tt.expectedErr == nil || err == nil && tt.expectedErr != nil || (err != nil && err.Error() != tt.expectedErr.Error()) {
 				t.Errorf("expected error: %v, got: %v", tt.expectedErr, err)
 			}
-			if result != tt.expectedRes {
+			if !reflect.DeepEqual(result, tt.expectedRes) {
 				t.Errorf("expected result: %+v, got: %+v", tt.expectedRes, result)
 			}
 
@@ -195,11 +206,18 @@ func TestFindAll(t *testing.T) {
 					AddRow(2, "Test Task 2", "Test description 2")
 				mock.ExpectQuery("^SELECT \\* FROM `tasks`").
 					WillReturnRows(rows)
+				mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+				mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+				mock.ExpectQuery("^SELECT \\* FROM `task_tags` WHERE `task_tags`.`task_id` IN \\(\\?,\\?\\)$").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
 			},
 			expectedErr: nil,
 			expectedRes: []domain.Task{
-				{ID: 1, Title: "Test Task 1", Description: "Test description 1"},
-				{ID: 2, Title: "Test Task 2", Description: "Test description 2"},
+				{ID: 1, Title: "Test Task 1", Description: "Test description 1", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}},
+				{ID: 2, Title: "Test Task 2", Description: "Test description 2", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}},
 			},
 		},
 		{
@@ -241,7 +259,7 @@ func TestFindAll(t *testing.T) {
 				t.Errorf("expected result length: %d, got: %d", len(tt.expectedRes), len(result))
 			}
 			for i := range result {
-				if result[i] != tt.expectedRes[i] {
+				if !reflect.DeepEqual(result[i], tt.expectedRes[i]) {
 					t.Errorf("expected task at index %d: %+v, got: %+v", i, tt.expectedRes[i], result[i])
 				}
 			}
@@ -254,6 +272,206 @@ func TestFindAll(t *testing.T) {
 	}
 }
 
+func TestFindByTags(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	// Matching all tags requires every named tag to be linked to the task
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Page triage")
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NULL AND id IN \\(SELECT task_tags.task_id FROM `task_tags` JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN \\(\\?,\\?\\) GROUP BY `task_tags`.`task_id` HAVING COUNT\\(DISTINCT tags.id\\) = \\?\\) ORDER BY id$").
+		WithArgs("oncall", "backend", 2).
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags` WHERE `task_tags`.`task_id` = \\?$").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}).AddRow(1, 1).AddRow(1, 2))
+	mock.ExpectQuery("^SELECT \\* FROM `tags` WHERE `tags`.`id` IN \\(\\?,\\?\\)$").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "oncall").AddRow(2, "backend"))
+
+	result, err := repo.FindByTags([]string{"oncall", "backend"}, true)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Task{{ID: 1, Title: "Page triage", Tags: []domain.Tag{{ID: 1, Name: "oncall"}, {ID: 2, Name: "backend"}}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindOpenDueBefore(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	before := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC)
+
+	// Only open tasks with a due date up to the cut-off should be requested, earliest first
+	rows := sqlmock.NewRows([]string{"id", "title", "due_date", "due_has_time"}).
+		AddRow(1, "Pay invoice", due, true)
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND \\(completed = \\? AND due_date IS NOT NULL AND due_date <= \\?\\) ORDER BY due_date$").
+		WithArgs(false, before).
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	result, err := repo.FindOpenDueBefore(before)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := domain.Task{ID: 1, Title: "Pay invoice", DueDate: &due, DueHasTime: true}
+	if len(result) != 1 || result[0].ID != expected.ID || !result[0].DueDate.Equal(due) || !result[0].DueHasTime {
+		t.Errorf("expected result: [%+v], got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCountByProject(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	// Counts should be grouped per project, leaving out tasks without one
+	rows := sqlmock.NewRows([]string{"project_id", "total", "open_tasks"}).
+		AddRow(1, 3, 1).
+		AddRow(2, 1, 0)
+	mock.ExpectQuery("^SELECT project_id, COUNT\\(\\*\\) AS total, SUM\\(CASE WHEN completed THEN 0 ELSE 1 END\\) AS open_tasks FROM `tasks` WHERE project_id IS NOT NULL AND deleted_at IS NULL GROUP BY `project_id`$").
+		WillReturnRows(rows)
+
+	result, err := repo.CountByProject()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := map[uint]domain.TaskCounts{1: {Total: 3, Open: 1}, 2: {Total: 1, Open: 0}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindReady(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	// Open tasks should be excluded when any of their blockers is still open
+	rows := sqlmock.NewRows([]string{"id", "title", "completed"}).
+		AddRow(7, "Design schema", false)
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND \\(completed = \\? AND id NOT IN \\(SELECT task_dependencies.task_id FROM `task_dependencies` JOIN tasks blockers ON blockers.id = task_dependencies.blocker_id WHERE blockers.completed = \\? AND blockers.deleted_at IS NULL\\)\\) ORDER BY id$").
+		WithArgs(false, false).
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	result, err := repo.FindReady()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Task{{ID: 7, Title: "Design schema", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
 // func TestUpdate(t *testing.T) {
 // 	// Initialize sqlmock
 // 	db, mock, err := sqlmock.New()
@@ -352,7 +570,7 @@ func TestFindAll(t *testing.T) {
 // 			if err != nil && tt.expectedErr == nil || err == nil && tt.expectedErr != nil || (err != nil && err.Error() != tt.expectedErr.Error()) {
 // 				t.Errorf("expected error: %v, got: %v", tt.expectedErr, err)
 // 			}
-// 			if result != tt.expectedRes {
+// 			if !reflect.DeepEqual(result, tt.expectedRes) {
 // 				t.Errorf("expected result: %+v, got: %+v", tt.expectedRes, result)
 // 			}
 
@@ -363,3 +581,377 @@ func TestFindAll(t *testing.T) {
 // 		})
 // 	}
 // }
+
+func TestFindUnassigned(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "assignee_id"}).
+		AddRow(3, "Triage inbox", nil)
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NULL AND assignee_id IS NULL ORDER BY id$").
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	result, err := repo.FindUnassigned()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Task{{ID: 3, Title: "Triage inbox", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindArchived_EscapesWildcards(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	rows := sqlmock.NewRows([]string{"id", "title"}).
+		AddRow(4, "Reach 100% coverage")
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NOT NULL AND \\(title LIKE \\? ESCAPE '!' OR description LIKE \\? ESCAPE '!'\\) ORDER BY archived_at DESC,id$").
+		WithArgs(`%100!%%`, `%100!%%`).
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	result, err := repo.FindArchived("100%")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Task{{ID: 4, Title: "Reach 100% coverage", Tags: []domain.Tag{}, Checklist: []domain.ChecklistItem{}, FieldValues: []domain.FieldValue{}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestMove_LocksTasksBeforeReadingNeighbour(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	// Task 5 moves before task 2, whose neighbour in front is at 1 * PositionGap
+	mock.ExpectBegin()
+	mock.ExpectQuery("^SELECT `id` FROM `tasks` WHERE id IN \\(\\?,\\?\\) AND deleted_at IS NULL ORDER BY id FOR UPDATE$").
+		WithArgs(5, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(5))
+	mock.ExpectQuery("^SELECT `position` FROM `tasks` WHERE id = \\?$").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2 * domain.PositionGap))
+	mock.ExpectQuery("^SELECT `position` FROM `tasks` WHERE id <> \\? AND \\(position < \\? OR \\(position = \\? AND id < \\?\\)\\) ORDER BY position DESC,id DESC LIMIT \\? FOR UPDATE$").
+		WithArgs(5, 2*domain.PositionGap, 2*domain.PositionGap, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(domain.PositionGap))
+	mock.ExpectExec("^UPDATE `tasks` SET `position`=\\? WHERE id = \\?$").
+		WithArgs(domain.PositionGap+domain.PositionGap/2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND `tasks`.`id` = \\?").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).AddRow(5, domain.PositionGap+domain.PositionGap/2))
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	task, err := repo.Move(5, 2, false)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if task.Position != domain.PositionGap+domain.PositionGap/2 {
+		t.Errorf("expected position %d, got %d", domain.PositionGap+domain.PositionGap/2, task.Position)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindByFieldValues(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	customer, points := "ACME", 5.0
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NULL AND id IN \\(SELECT `task_id` FROM `field_values` WHERE field_id = \\? AND text_value = \\?\\) AND id IN \\(SELECT `task_id` FROM `field_values` WHERE field_id = \\? AND number_value = \\?\\) ORDER BY id$").
+		WithArgs(1, customer, 2, points).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(7, "Renew licence"))
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values` WHERE `field_values`.`task_id` = \\? ORDER BY field_id$").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id", "text_value", "number_value"}).
+			AddRow(7, 1, customer, nil).
+			AddRow(7, 2, nil, points))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	result, err := repo.FindByFieldValues([]domain.FieldValue{
+		{FieldID: 1, TextValue: &customer},
+		{FieldID: 2, NumberValue: &points},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []domain.Task{{
+		ID:          7,
+		Title:       "Renew licence",
+		Tags:        []domain.Tag{},
+		Checklist:   []domain.ChecklistItem{},
+		FieldValues: []domain.FieldValue{{TaskID: 7, FieldID: 1, TextValue: &customer}, {TaskID: 7, FieldID: 2, NumberValue: &points}},
+	}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected result: %+v, got: %+v", expected, result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestQuery_KeysetPage(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	completed := false
+	after := domain.TaskCursor{ID: 5, Title: "Budget"}
+	query := domain.TaskQuery{Completed: &completed, Sort: domain.SortByTitle, Desc: true, Limit: 2, After: &after}
+
+	// One row more than the limit tells the repository another page follows
+	rows := sqlmock.NewRows([]string{"id", "title"}).
+		AddRow(3, "Billing").
+		AddRow(9, "Backlog").
+		AddRow(2, "Archive")
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NULL AND tasks.completed = \\? AND \\(tasks.title < \\? OR \\(tasks.title = \\? AND tasks.id < \\?\\)\\) ORDER BY tasks.title DESC,tasks.id DESC LIMIT \\?$").
+		WithArgs(false, "budget", "budget", 5, 3).
+		WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	page, err := repo.Query(query)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(page.Tasks) != 2 || page.Tasks[0].ID != 3 || page.Tasks[1].ID != 9 {
+		t.Errorf("expected tasks 3 and 9, got: %+v", page.Tasks)
+	}
+	if page.Next == nil || page.Next.ID != 9 || page.Next.Title != "Backlog" {
+		t.Errorf("expected a cursor at task 9, got: %+v", page.Next)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestQuery_PriorityKeysetPage(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	due := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	after := domain.TaskCursor{ID: 5, Priority: domain.PriorityHigh, DueDate: &due}
+	query := domain.TaskQuery{Sort: domain.SortByPriority, Desc: true, Limit: 2, After: &after}
+
+	// Tasks of the cursor's priority follow it when due later or not at all
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NULL AND \\(tasks.priority < \\? OR \\(tasks.priority = \\? AND \\(tasks.due_date > \\? OR tasks.due_date IS NULL OR \\(tasks.due_date = \\? AND tasks.id < \\?\\)\\)\\)\\) ORDER BY tasks.priority DESC,tasks.due_date IS NULL,tasks.due_date ASC,tasks.id DESC LIMIT \\?$").
+		WithArgs(int(domain.PriorityHigh), int(domain.PriorityHigh), due, due, 5, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "priority"}).AddRow(2, "Review", domain.PriorityHigh))
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	page, err := repo.Query(query)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(page.Tasks) != 1 || page.Tasks[0].ID != 2 || page.Next != nil {
+		t.Errorf("expected only task 2, got: %+v", page)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSearch_FullTextRanking(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTaskRepository(gormDB)
+
+	mock.ExpectQuery("^SELECT tasks.id, MATCH\\(tasks.title\\) AGAINST \\(\\? IN BOOLEAN MODE\\) \\* 2 \\+ MATCH\\(tasks.title, tasks.description\\) AGAINST \\(\\? IN BOOLEAN MODE\\) AS score FROM `tasks` WHERE tasks.deleted_at IS NULL AND MATCH\\(tasks.title, tasks.description\\) AGAINST \\(\\? IN BOOLEAN MODE\\) ORDER BY score DESC,tasks.id LIMIT \\?$").
+		WithArgs("+quart* +rep*", "+quart* +rep*", "+quart* +rep*", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}).AddRow(8, 3.5).AddRow(2, 1.25))
+	mock.ExpectQuery("^SELECT \\* FROM `tasks` WHERE tasks.deleted_at IS NULL AND tasks.id IN \\(\\?,\\?\\)$").
+		WithArgs(8, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(2, "Plan quarter").AddRow(8, "Quarterly report"))
+	mock.ExpectQuery("^SELECT \\* FROM `checklist_items`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "position"}))
+	mock.ExpectQuery("^SELECT \\* FROM `field_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "field_id"}))
+	mock.ExpectQuery("^SELECT \\* FROM `task_tags`").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "tag_id"}))
+
+	hits, err := repo.Search([]string{"quart", "rep"}, 10)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Hits keep the relevance order of the ranking query
+	if len(hits) != 2 || hits[0].Task.ID != 8 || hits[0].Score != 3.5 || hits[1].Task.ID != 2 {
+		t.Errorf("expected tasks 8 then 2, got: %+v", hits)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
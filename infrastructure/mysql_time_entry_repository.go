@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MySQLTimeEntryRepository struct {
+	db    *gorm.DB
+	tasks domain.TaskRepository // Resolves which tasks belong to a project when they are not stored in db
+}
+
+func NewMySQLTimeEntryRepository(db *gorm.DB) *MySQLTimeEntryRepository {
+	return &MySQLTimeEntryRepository{db: db}
+}
+
+// NewMySQLTimeEntryRepositoryFor returns a repository for time entries whose
+// tasks are stored in tasks rather than in db
+func NewMySQLTimeEntryRepositoryFor(db *gorm.DB, tasks domain.TaskRepository) *MySQLTimeEntryRepository {
+	return &MySQLTimeEntryRepository{db: db, tasks: tasks}
+}
+
+func (r *MySQLTimeEntryRepository) Save(entry domain.TimeEntry) (uint, error) {
+	result := r.db.Create(&entry)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return entry.ID, nil
+}
+
+// Start saves a running timer unless the user already has one. The user's row
+// is locked for the duration of the check so concurrent starts are serialized.
+func (r *MySQLTimeEntryRepository) Start(entry domain.TimeEntry) (uint, error) {
+	if entry.UserID == nil {
+		return r.Save(entry)
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, *entry.UserID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrUserNotFound
+		} else if err != nil {
+			return err
+		}
+
+		var running int64
+		if err := tx.Model(&domain.TimeEntry{}).Where("user_id = ? AND ended_at IS NULL", *entry.UserID).Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return domain.ErrTimerRunning
+		}
+		return tx.Create(&entry).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return entry.ID, nil
+}
+
+func (r *MySQLTimeEntryRepository) FindByID(id uint) (domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	result := r.db.First(&entry, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return entry, domain.ErrTimeEntryNotFound
+	}
+	return entry, result.Error
+}
+
+// FindByTask returns a task's time entries, oldest first
+func (r *MySQLTimeEntryRepository) FindByTask(taskID uint) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+	result := r.db.Where("task_id = ?", taskID).Order("started_at").Order("id").Find(&entries)
+	return entries, result.Error
+}
+
+// FindByProject returns the time entries of every task in a project, oldest first
+func (r *MySQLTimeEntryRepository) FindByProject(projectID uint) ([]domain.TimeEntry, error) {
+	var tasks any = r.db.Model(&domain.Task{}).Select("id").Where("project_id = ?", projectID)
+	if r.tasks != nil {
+		projectTasks, err := r.tasks.FindByProject(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(projectTasks) == 0 {
+			return []domain.TimeEntry{}, nil
+		}
+		ids := make([]uint, len(projectTasks))
+		for i, task := range projectTasks {
+			ids[i] = task.ID
+		}
+		tasks = ids
+	}
+
+	var entries []domain.TimeEntry
+	result := r.db.Where("task_id IN (?)", tasks).Order("started_at").Order("id").Find(&entries)
+	return entries, result.Error
+}
+
+// FindRunning returns the user's running timer
+func (r *MySQLTimeEntryRepository) FindRunning(userID uint) (domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	result := r.db.Where("user_id = ? AND ended_at IS NULL", userID).First(&entry)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return entry, domain.ErrTimeEntryNotFound
+	}
+	return entry, result.Error
+}
+
+func (r *MySQLTimeEntryRepository) Update(entry domain.TimeEntry) (domain.TimeEntry, error) {
+	if err := r.db.Save(&entry).Error; err != nil {
+		return domain.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (r *MySQLTimeEntryRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.TimeEntry{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrTimeEntryNotFound
+	}
+	return result.Error
+}
+
+// DeleteByTask removes every time entry of a task
+func (r *MySQLTimeEntryRepository) DeleteByTask(taskID uint) error {
+	return r.db.Where("task_id = ?", taskID).Delete(&domain.TimeEntry{}).Error
+}
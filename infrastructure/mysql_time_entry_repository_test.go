@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStart_TimerAlreadyRunning(t *testing.T) {
+	// Initialize sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock db: %v", err)
+	}
+	defer db.Close()
+
+	// Create GORM DB from sqlmock
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize gorm: %v", err)
+	}
+
+	repo := NewMySQLTimeEntryRepository(gormDB)
+
+	// The user's row is locked before looking for a running timer
+	mock.ExpectBegin()
+	mock.ExpectQuery("^SELECT \\* FROM `users` WHERE `users`.`id` = \\? ORDER BY `users`.`id` LIMIT \\? FOR UPDATE$").
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Ada"))
+	mock.ExpectQuery("^SELECT count\\(\\*\\) FROM `time_entries` WHERE user_id = \\? AND ended_at IS NULL$").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	userID := uint(3)
+	_, err = repo.Start(domain.TimeEntry{TaskID: 1, UserID: &userID, StartedAt: time.Now()})
+	if !errors.Is(err, domain.ErrTimerRunning) {
+		t.Errorf("expected ErrTimerRunning, got: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
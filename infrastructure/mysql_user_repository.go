@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+type MySQLUserRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLUserRepository(db *gorm.DB) *MySQLUserRepository {
+	return &MySQLUserRepository{db: db}
+}
+
+func (r *MySQLUserRepository) Save(user domain.User) (uint, error) {
+	result := r.db.Create(&user)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return 0, fmt.Errorf("email %q %w", user.Email, domain.ErrDuplicate)
+	}
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return user.ID, nil
+}
+
+func (r *MySQLUserRepository) FindByID(id uint) (domain.User, error) {
+	var user domain.User
+	result := r.db.First(&user, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return user, domain.ErrUserNotFound
+	}
+	return user, result.Error
+}
+
+func (r *MySQLUserRepository) FindByEmail(email string) (domain.User, error) {
+	var user domain.User
+	result := r.db.Where("email = ?", email).First(&user)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return user, domain.ErrUserNotFound
+	}
+	return user, result.Error
+}
+
+func (r *MySQLUserRepository) FindAll() ([]domain.User, error) {
+	var users []domain.User
+	result := r.db.Order("id").Find(&users)
+	return users, result.Error
+}
+
+func (r *MySQLUserRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.User{}, id)
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return result.Error
+}
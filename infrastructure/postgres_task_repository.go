@@ -0,0 +1,63 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+// PostgresTaskRepository stores tasks in a PostgreSQL database
+type PostgresTaskRepository struct {
+	*gormTaskRepository
+}
+
+// taskSearchDocument is the weighted text search vector of a task, title
+// words weighted A and description words B. The GIN index made by
+// CreateSearchIndexes is on this exact expression, so it must not change
+// without the index.
+const taskSearchDocument = "(setweight(to_tsvector('simple', coalesce(tasks.title, '')), 'A') || " +
+	"setweight(to_tsvector('simple', coalesce(tasks.description, '')), 'B'))"
+
+func NewPostgresTaskRepository(db *gorm.DB) *PostgresTaskRepository {
+	return &PostgresTaskRepository{&gormTaskRepository{db: db, dialect: postgresTaskDialect{}}}
+}
+
+// postgresTaskDialect orders and matches titles ignoring case, as MySQL's
+// default collation does, and searches with text search vectors
+type postgresTaskDialect struct{}
+
+func (postgresTaskDialect) sortColumn(sort domain.TaskSort) string {
+	if sort == domain.SortByTitle {
+		return "LOWER(tasks.title)"
+	}
+	return taskSortColumns[sort]
+}
+
+func (postgresTaskDialect) like() string {
+	return "ILIKE"
+}
+
+// rank ranks tasks with ts_rank over taskSearchDocument, counting title
+// matches twice. Every term must match and matches words it is a prefix of.
+// The simple configuration is used, so words are neither stemmed nor dropped
+// as stopwords.
+func (postgresTaskDialect) rank(db *gorm.DB, terms []string, limit int) ([]rankedTask, error) {
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		// Terms are only letters and digits, so they cannot be read as tsquery operators
+		prefixes[i] = term + ":*"
+	}
+	query := strings.Join(prefixes, " & ")
+
+	var ranked []rankedTask
+	result := db.Model(&domain.Task{}).
+		Select("tasks.id, ts_rank('{0, 0, 0.5, 1}', "+taskSearchDocument+", to_tsquery('simple', ?)) AS score", query).
+		Where("tasks.deleted_at IS NULL").
+		Where(taskSearchDocument+" @@ to_tsquery('simple', ?)", query).
+		Order("score DESC").Order("tasks.id").
+		Limit(limit).
+		Scan(&ranked)
+	return ranked, result.Error
+}
@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// titleWeight is how much more a word in a task's title counts than one in its description
+const titleWeight = 2
+
+// prefixWeight scales the score of a word a term is only a prefix of, so
+// whole-word matches rank first
+const prefixWeight = 0.5
+
+// occurrences counts how often a word appears in a task
+type occurrences struct {
+	title, description int
+}
+
+// searchIndex is an inverted index from the words of task titles and
+// descriptions to the tasks containing them. It is not safe for concurrent
+// use; MemoryTaskRepository guards it with its own mutex.
+type searchIndex struct {
+	postings map[string]map[uint]occurrences // word to the tasks containing it
+	words    []string                        // every indexed word, sorted for prefix lookups
+	indexed  map[uint][]string               // task to the distinct words it was indexed under
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[uint]occurrences),
+		indexed:  make(map[uint][]string),
+	}
+}
+
+// add indexes the task, replacing whatever was indexed for it before
+func (x *searchIndex) add(task domain.Task) {
+	x.remove(task.ID)
+
+	counts := make(map[string]occurrences)
+	for _, word := range domain.Tokenize(task.Title) {
+		c := counts[word]
+		c.title++
+		counts[word] = c
+	}
+	for _, word := range domain.Tokenize(task.Description) {
+		c := counts[word]
+		c.description++
+		counts[word] = c
+	}
+
+	words := make([]string, 0, len(counts))
+	for word, c := range counts {
+		tasks, ok := x.postings[word]
+		if !ok {
+			tasks = make(map[uint]occurrences)
+			x.postings[word] = tasks
+			i, _ := slices.BinarySearch(x.words, word)
+			x.words = slices.Insert(x.words, i, word)
+		}
+		tasks[task.ID] = c
+		words = append(words, word)
+	}
+	x.indexed[task.ID] = words
+}
+
+// remove drops the task from the index
+func (x *searchIndex) remove(id uint) {
+	for _, word := range x.indexed[id] {
+		tasks := x.postings[word]
+		delete(tasks, id)
+		if len(tasks) == 0 {
+			delete(x.postings, word)
+			if i, found := slices.BinarySearch(x.words, word); found {
+				x.words = slices.Delete(x.words, i, i+1)
+			}
+		}
+	}
+	delete(x.indexed, id)
+}
+
+// search scores the tasks matching every term and returns their IDs, most
+// relevant first. A word's contribution is weighted by how rare it is across
+// the indexed tasks, the way TF-IDF does.
+func (x *searchIndex) search(terms []string) ([]uint, map[uint]float64) {
+	total := float64(len(x.indexed))
+	var scores map[uint]float64
+	for _, term := range terms {
+		termScores := make(map[uint]float64)
+		// Every word the term is a prefix of sorts right at or after the term
+		for i := sort.SearchStrings(x.words, term); i < len(x.words) && strings.HasPrefix(x.words[i], term); i++ {
+			word := x.words[i]
+			tasks := x.postings[word]
+			idf := math.Log(1 + total/float64(len(tasks)))
+			if word != term {
+				idf *= prefixWeight
+			}
+			for id, c := range tasks {
+				termScores[id] += float64(titleWeight*c.title+c.description) * idf
+			}
+		}
+
+		// Tasks must match every term
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for id, score := range scores {
+			if extra, ok := termScores[id]; ok {
+				scores[id] = score + extra
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	ids := make([]uint, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b uint) int {
+		if c := cmp.Compare(scores[b], scores[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return ids, scores
+}
@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+func TestMemorySearch_RanksAndMatchesPrefixes(t *testing.T) {
+	repo := NewMockTaskRepository()
+	report, _ := repo.Save(domain.Task{Title: "Quarterly report", Description: "Numbers for finance"})
+	notes, _ := repo.Save(domain.Task{Title: "Meeting notes", Description: "Mention the report deadline"})
+	repo.Save(domain.Task{Title: "Groceries", Description: "Milk"})
+
+	hits, err := repo.Search(domain.SearchTerms("rep"), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Task.ID != report || hits[1].Task.ID != notes {
+		t.Fatalf("expected the title match first, then the description match, got: %+v", hits)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("expected the title match to score higher: %v <= %v", hits[0].Score, hits[1].Score)
+	}
+
+	// Every term has to match
+	hits, _ = repo.Search(domain.SearchTerms("report finance"), 10)
+	if len(hits) != 1 || hits[0].Task.ID != report {
+		t.Errorf("expected only the quarterly report, got: %+v", hits)
+	}
+}
+
+func TestMemorySearch_FollowsUpdatesAndTrash(t *testing.T) {
+	repo := NewMockTaskRepository()
+	id, _ := repo.Save(domain.Task{Title: "Draft budget"})
+
+	task, _ := repo.FindByID(id)
+	task.Title = "Final forecast"
+	repo.Update(task)
+	if hits, _ := repo.Search([]string{"budget"}, 10); len(hits) != 0 {
+		t.Errorf("expected the old title to be unindexed, got: %+v", hits)
+	}
+	if hits, _ := repo.Search([]string{"forecast"}, 10); len(hits) != 1 {
+		t.Errorf("expected the new title to be indexed, got: %+v", hits)
+	}
+
+	repo.Trash(id, time.Now())
+	if hits, _ := repo.Search([]string{"forecast"}, 10); len(hits) != 0 {
+		t.Errorf("expected trashed tasks to be left out, got: %+v", hits)
+	}
+	repo.Restore(id)
+	if hits, _ := repo.Search([]string{"forecast"}, 10); len(hits) != 1 {
+		t.Errorf("expected restored tasks to be found again, got: %+v", hits)
+	}
+}
@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// SMTPNotifier mails fired reminders through an unauthenticated SMTP relay,
+// such as a local mail catcher used as a stand-in for a real mail server
+type SMTPNotifier struct {
+	addr string // host:port of the relay
+	from string
+	to   string // Fallback recipient for tasks without an assignee
+}
+
+func NewSMTPNotifier(addr, from, to string) *SMTPNotifier {
+	return &SMTPNotifier{addr: addr, from: from, to: to}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	to := n.to
+	if notification.Recipient != nil && notification.Recipient.Email != "" {
+		to = notification.Recipient.Email
+	}
+	if to == "" {
+		return errors.New("reminder has no recipient")
+	}
+
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	host, _, _ := net.SplitHostPort(n.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(reminderMessage(n.from, to, notification)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// reminderMessage builds the RFC 5322 message mailed for a reminder
+func reminderMessage(from, to string, notification domain.Notification) []byte {
+	task := notification.Task
+	// Header values must not carry line breaks from user input
+	title := strings.NewReplacer("\r", " ", "\n", " ").Replace(task.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: Reminder: %s\r\n", title)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "Task %d: %s\r\n", task.ID, title)
+	if task.DueDate != nil {
+		fmt.Fprintf(&b, "Due: %s\r\n", task.DueDate.In(task.DueLocation()).Format(time.RFC1123))
+	}
+	if task.Description != "" {
+		b.WriteString("\r\n")
+		description := strings.ReplaceAll(task.Description, "\r\n", "\n")
+		b.WriteString(strings.ReplaceAll(description, "\n", "\r\n"))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteTaskRepository stores tasks in a SQLite database
+type SQLiteTaskRepository struct {
+	*gormTaskRepository
+}
+
+func NewSQLiteTaskRepository(db *gorm.DB) *SQLiteTaskRepository {
+	return &SQLiteTaskRepository{&gormTaskRepository{db: db, dialect: sqliteTaskDialect{}}}
+}
+
+// sqliteTaskDialect orders titles ignoring case, as MySQL's default collation
+// does, and searches with an FTS5 table
+type sqliteTaskDialect struct{}
+
+func (sqliteTaskDialect) sortColumn(sort domain.TaskSort) string {
+	if sort == domain.SortByTitle {
+		return "tasks.title COLLATE NOCASE"
+	}
+	return taskSortColumns[sort]
+}
+
+// like returns LIKE, which ignores the case of ASCII letters in SQLite
+func (sqliteTaskDialect) like() string {
+	return "LIKE"
+}
+
+// rank ranks tasks with the FTS5 table made by CreateSearchIndexes, using
+// bm25 with title matches weighted twice. Every term must match and matches
+// words it is a prefix of.
+func (sqliteTaskDialect) rank(db *gorm.DB, terms []string, limit int) ([]rankedTask, error) {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		// Terms are only letters and digits, so quoting them is enough to keep
+		// FTS5 from reading them as operators
+		quoted[i] = `"` + term + `"*`
+	}
+	match := strings.Join(quoted, " ")
+
+	var ranked []rankedTask
+	result := db.Table("tasks_search").
+		Select("tasks.id, -bm25(tasks_search, 2.0, 1.0) AS score").
+		Joins("JOIN tasks ON tasks.id = tasks_search.rowid").
+		Where("tasks_search MATCH ?", match).
+		Where("tasks.deleted_at IS NULL").
+		Order("score DESC").Order("tasks.id").
+		Limit(limit).
+		Scan(&ranked)
+	return ranked, result.Error
+}
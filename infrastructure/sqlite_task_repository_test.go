@@ -0,0 +1,232 @@
+package infrastructure
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/krishnakumarkp/to-do/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSQLiteRepository migrates a fresh SQLite file the way main does and returns a repository on it
+func newSQLiteRepository(t *testing.T) *SQLiteTaskRepository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "todo.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&domain.Task{}, &domain.Tag{}, &domain.TaskDependency{}, &domain.ChecklistItem{}, &domain.CustomField{}, &domain.FieldValue{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := CreateSearchIndexes(db); err != nil {
+		t.Fatalf("failed to create search table: %v", err)
+	}
+	repo, ok := NewTaskRepository(db).(*SQLiteTaskRepository)
+	if !ok {
+		t.Fatalf("expected a SQLiteTaskRepository for a sqlite connection")
+	}
+	return repo
+}
+
+func saveTasks(t *testing.T, repo domain.TaskRepository, tasks ...domain.Task) []uint {
+	t.Helper()
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		id, err := repo.Save(task)
+		if err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func taskIDs(tasks []domain.Task) []uint {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// tasksByPosition returns the listed tasks of the repository in their manual order
+func tasksByPosition(t *testing.T, repo domain.TaskRepository) []domain.Task {
+	t.Helper()
+	page, err := repo.Query(domain.TaskQuery{Sort: domain.SortByPosition, Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return page.Tasks
+}
+
+func TestSQLite_QueryPagesByTitleIgnoringCase(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "banana"},
+		domain.Task{Title: "Apple"},
+		domain.Task{Title: "cherry"},
+		domain.Task{Title: "apricot 50%"},
+	)
+
+	query := domain.TaskQuery{Sort: domain.SortByTitle, Limit: 2}
+	page, err := repo.Query(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(page.Tasks); len(got) != 2 || got[0] != ids[1] || got[1] != ids[3] || page.Next == nil {
+		t.Fatalf("unexpected first page %v, next %v", got, page.Next)
+	}
+
+	query.After = page.Next
+	page, err = repo.Query(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(page.Tasks); len(got) != 2 || got[0] != ids[0] || got[1] != ids[2] || page.Next != nil {
+		t.Fatalf("unexpected second page %v, next %v", got, page.Next)
+	}
+
+	page, err = repo.Query(domain.TaskQuery{Text: "50%", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(page.Tasks); len(got) != 1 || got[0] != ids[3] {
+		t.Errorf("expected only the task containing 50%%, got %v", got)
+	}
+}
+
+func TestSQLite_QueryPagesByPriorityThenDueDate(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	memory := NewMockTaskRepository()
+
+	soon := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 7)
+	for _, task := range []domain.Task{
+		{Title: "no due date", Priority: domain.PriorityHigh},
+		{Title: "due later", Priority: domain.PriorityHigh, DueDate: &later},
+		{Title: "due soon", Priority: domain.PriorityHigh, DueDate: &soon},
+		{Title: "also due later", Priority: domain.PriorityHigh, DueDate: &later},
+		{Title: "low", Priority: domain.PriorityLow, DueDate: &soon},
+		{Title: "also no due date", Priority: domain.PriorityHigh},
+	} {
+		saveTasks(t, repo, task)
+		saveTasks(t, memory, task)
+	}
+
+	// Most important first, the earliest due date first within a priority
+	// and tasks without one last
+	want, _ := memory.Query(domain.TaskQuery{Sort: domain.SortByPriority, Desc: true, Limit: 10})
+	if got := taskIDs(want.Tasks); !slices.Equal(got, []uint{3, 4, 2, 6, 1, 5}) {
+		t.Fatalf("unexpected order %v", got)
+	}
+
+	for _, desc := range []bool{true, false} {
+		// Page two tasks at a time, so pages start inside every tie
+		var got []uint
+		query := domain.TaskQuery{Sort: domain.SortByPriority, Desc: desc, Limit: 2}
+		for {
+			page, err := repo.Query(query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, taskIDs(page.Tasks)...)
+			if page.Next == nil {
+				break
+			}
+			query.After = page.Next
+		}
+		want, _ := memory.Query(domain.TaskQuery{Sort: domain.SortByPriority, Desc: desc, Limit: 10})
+		if !slices.Equal(got, taskIDs(want.Tasks)) {
+			t.Errorf("desc %v: expected %v, got %v", desc, taskIDs(want.Tasks), got)
+		}
+	}
+}
+
+func TestSQLite_SearchFollowsChanges(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "Write report", Description: "quarterly numbers"},
+		domain.Task{Title: "Call the bank", Description: "ask about the report"},
+		domain.Task{Title: "Water plants"},
+	)
+
+	hits, err := repo.Search([]string{"rep"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Task.ID != ids[0] || hits[1].Task.ID != ids[1] {
+		t.Fatalf("expected the title match to rank first, got %+v", hits)
+	}
+
+	task, err := repo.FindByID(ids[2])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	task.Title = "Report plants"
+	if _, err := repo.Update(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Trash(ids[0], time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Delete(ids[1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hits, err = repo.Search([]string{"report"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Task.ID != ids[2] {
+		t.Errorf("expected only the renamed task, got %+v", hits)
+	}
+}
+
+func TestSQLite_Move(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ids := saveTasks(t, repo, domain.Task{Title: "first"}, domain.Task{Title: "second"}, domain.Task{Title: "third"})
+
+	if _, err := repo.Move(ids[2], ids[0], false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tasks := tasksByPosition(t, repo)
+	if got := taskIDs(tasks); len(got) != 3 || got[0] != ids[2] || got[1] != ids[0] || got[2] != ids[1] {
+		t.Errorf("unexpected order %v", got)
+	}
+}
+
+func TestSQLite_ArchiveCompletedBeforeReturnsArchivedTasks(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	cutoff := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	old, recent := cutoff.AddDate(0, 0, -1), cutoff.AddDate(0, 0, 1)
+	ids := saveTasks(t, repo,
+		domain.Task{Title: "old", Completed: true, CompletedAt: &old},
+		domain.Task{Title: "recent", Completed: true, CompletedAt: &recent},
+		domain.Task{Title: "open"},
+	)
+
+	at := cutoff.AddDate(0, 1, 0)
+	archived, err := repo.ArchiveCompletedBefore(cutoff, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(archived); len(got) != 1 || got[0] != ids[0] {
+		t.Fatalf("expected only task %d archived, got %v", ids[0], got)
+	}
+	if archived[0].ArchivedAt == nil || !archived[0].ArchivedAt.Equal(at) {
+		t.Errorf("expected the archived task to carry its archive time, got %v", archived[0].ArchivedAt)
+	}
+	listed, err := repo.FindAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(listed); slices.Contains(got, ids[0]) || len(got) != 2 {
+		t.Errorf("expected the archived task left out of the listing, got %v", got)
+	}
+}
@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// taskRecord is the form tasks are written to disk in. It is JSON rather than
+// gob, which leaves out values behind pointers when they are zero, so a number
+// field set to 0 would come back unset. Field values are written with their
+// own fields, as their JSON form is meant for clients and loses which kind of
+// value they hold.
+type taskRecord struct {
+	recordedTask
+	FieldValues []fieldValueRecord `json:"custom_fields,omitempty"`
+}
+
+// recordedTask is a task without the methods that shape its JSON for clients
+type recordedTask domain.Task
+
+// fieldValueRecord is the form a custom field value is written to disk in
+type fieldValueRecord struct {
+	FieldID     uint       `json:"field_id"`
+	TextValue   *string    `json:"text,omitempty"`
+	NumberValue *float64   `json:"number,omitempty"`
+	DateValue   *time.Time `json:"date,omitempty"`
+}
+
+// recordOf returns the record the task is written as
+func recordOf(task domain.Task) taskRecord {
+	record := taskRecord{recordedTask: recordedTask(task)}
+	record.recordedTask.FieldValues = nil
+	if len(task.FieldValues) > 0 {
+		record.FieldValues = make([]fieldValueRecord, len(task.FieldValues))
+		for i, value := range task.FieldValues {
+			record.FieldValues[i] = fieldValueRecord{FieldID: value.FieldID, TextValue: value.TextValue, NumberValue: value.NumberValue, DateValue: value.DateValue}
+		}
+	}
+	return record
+}
+
+// task returns the task the record was written from. The task IDs of its
+// checklist items and field values are not written, so they are set again.
+func (r taskRecord) task() domain.Task {
+	task := domain.Task(r.recordedTask)
+	for i := range task.Checklist {
+		task.Checklist[i].TaskID = task.ID
+	}
+	if len(r.FieldValues) > 0 {
+		task.FieldValues = make([]domain.FieldValue, len(r.FieldValues))
+		for i, value := range r.FieldValues {
+			task.FieldValues[i] = domain.FieldValue{TaskID: task.ID, FieldID: value.FieldID, TextValue: value.TextValue, NumberValue: value.NumberValue, DateValue: value.DateValue}
+		}
+	}
+	return task
+}
+
+// encodeTask returns the task as it is written to disk
+func encodeTask(task domain.Task) ([]byte, error) {
+	return json.Marshal(recordOf(task))
+}
+
+// decodeTask reads a task written by encodeTask
+func decodeTask(data []byte) (domain.Task, error) {
+	var record taskRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return domain.Task{}, err
+	}
+	return record.task(), nil
+}
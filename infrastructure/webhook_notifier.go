@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// WebhookNotifier posts fired reminders as JSON to a URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload is the JSON body posted for every fired reminder
+type webhookPayload struct {
+	Reminder  domain.Reminder `json:"reminder"`
+	Task      domain.Task     `json:"task"`
+	Recipient *domain.User    `json:"recipient,omitempty"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		Reminder:  notification.Reminder,
+		Task:      notification.Task,
+		Recipient: notification.Recipient,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+func TestWebhookNotifier_PostsReminder(t *testing.T) {
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL)
+	err := notifier.Notify(context.Background(), domain.Notification{
+		Reminder: domain.Reminder{ID: 4, TaskID: 1},
+		Task:     domain.Task{ID: 1, Title: "Pay rent"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Reminder.ID != 4 || received.Task.Title != "Pay rent" {
+		t.Errorf("unexpected payload: %+v", received)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL)
+	if err := notifier.Notify(context.Background(), domain.Notification{}); err == nil {
+		t.Error("expected an error for a 502 answer")
+	}
+}
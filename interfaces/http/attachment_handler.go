@@ -0,0 +1,114 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandler struct {
+	attachmentService application.AttachmentServiceInterface
+}
+
+func NewAttachmentHandler(attachmentService application.AttachmentServiceInterface) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// attachmentParams reads the task and attachment IDs from the path, answering 400 when either is invalid
+func attachmentParams(c *gin.Context) (uint, uint, bool) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	attachmentID, ok := idParam(c, "attachmentId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return 0, 0, false
+	}
+	return taskID, attachmentID, true
+}
+
+// UploadAttachment handles a multipart upload of a file, sent in the "file" field, to a task
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing file"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unreadable file"})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadAttachment(taskID, header.Filename, file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+// GetAttachments handles listing the attachments of a task
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(taskID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment handles downloading the content of an attachment
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.attachmentService.OpenAttachment(taskID, attachmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment handles deleting an attachment
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(taskID, attachmentID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,11 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// AttachmentHandlerInterface defines the contract for attachment handler operations.
+type AttachmentHandlerInterface interface {
+	UploadAttachment(c *gin.Context)
+	GetAttachments(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
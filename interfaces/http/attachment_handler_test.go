@@ -0,0 +1,89 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUploadAttachment(t *testing.T) {
+	mockService := new(application.MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+
+	attachment := domain.Attachment{ID: 3, TaskID: 1, FileName: "server.log", ContentType: "text/plain", Size: 5}
+	mockService.On("UploadAttachment", uint(1), "server.log", mock.Anything).Return(attachment, nil)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/attachments", handler.UploadAttachment)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "server.log")
+	part.Write([]byte("hello"))
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "UploadAttachment", uint(1), "server.log", mock.Anything)
+}
+
+func TestUploadAttachment_TooLarge(t *testing.T) {
+	mockService := new(application.MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+
+	mockService.On("UploadAttachment", uint(1), "dump.log", mock.Anything).Return(domain.Attachment{}, application.ErrTooLarge)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/attachments", handler.UploadAttachment)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "dump.log")
+	part.Write([]byte("hello"))
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	mockService := new(application.MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+
+	attachment := domain.Attachment{ID: 3, TaskID: 1, FileName: "server.log", ContentType: "text/plain", Size: 5}
+	mockService.On("OpenAttachment", uint(1), uint(3)).Return(attachment, io.NopCloser(strings.NewReader("hello")), nil)
+
+	router := gin.Default()
+	router.GET("/tasks/:id/attachments/:attachmentId", handler.DownloadAttachment)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/1/attachments/3", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "hello", recorder.Body.String())
+	assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=server.log`, recorder.Header().Get("Content-Disposition"))
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService application.CommentServiceInterface
+}
+
+func NewCommentHandler(commentService application.CommentServiceInterface) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// commentInput is the request body for posting a comment
+type commentInput struct {
+	Author string `json:"author" binding:"required"`
+	Body   string `json:"body" binding:"required"`
+}
+
+// editCommentInput is the request body for editing a comment
+type editCommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// commentParams reads the task and comment IDs from the path, answering 400 when either is invalid
+func commentParams(c *gin.Context) (uint, uint, bool) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	commentID, ok := idParam(c, "commentId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
+
+// AddComment handles posting a comment on a task
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input commentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.AddComment(taskID, input.Author, input.Body)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// GetComments handles listing the comments of a task
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	comments, err := h.commentService.GetComments(taskID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// GetComment handles retrieving a single comment of a task
+func (h *CommentHandler) GetComment(c *gin.Context) {
+	taskID, commentID, ok := commentParams(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.GetComment(taskID, commentID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// UpdateComment handles editing the body of a comment
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	taskID, commentID, ok := commentParams(c)
+	if !ok {
+		return
+	}
+
+	var input editCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.EditComment(taskID, commentID, input.Body)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment handles deleting a comment
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	taskID, commentID, ok := commentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteComment(taskID, commentID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,12 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// CommentHandlerInterface defines the contract for comment handler operations.
+type CommentHandlerInterface interface {
+	AddComment(c *gin.Context)
+	GetComments(c *gin.Context)
+	GetComment(c *gin.Context)
+	UpdateComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
+}
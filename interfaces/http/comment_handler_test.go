@@ -0,0 +1,72 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddComment(t *testing.T) {
+	mockService := new(application.MockCommentService)
+	handler := NewCommentHandler(mockService)
+
+	comment := domain.Comment{ID: 4, TaskID: 1, Author: "alice", Body: "Looks good"}
+	mockService.On("AddComment", uint(1), "alice", "Looks good").Return(comment, nil)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/comments", handler.AddComment)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/comments", bytes.NewBufferString(`{"author": "alice", "body": "Looks good"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.Comment
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, comment.Body, response.Body)
+}
+
+func TestUpdateComment_NotFound(t *testing.T) {
+	mockService := new(application.MockCommentService)
+	handler := NewCommentHandler(mockService)
+
+	mockService.On("EditComment", uint(1), uint(9), "Updated").Return(domain.Comment{}, domain.ErrCommentNotFound)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/comments/:commentId", handler.UpdateComment)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/comments/9", bytes.NewBufferString(`{"body": "Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestDeleteComment_InvalidID(t *testing.T) {
+	mockService := new(application.MockCommentService)
+	handler := NewCommentHandler(mockService)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id/comments/:commentId", handler.DeleteComment)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1/comments/abc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "DeleteComment")
+}
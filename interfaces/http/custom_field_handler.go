@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	customFieldService application.CustomFieldServiceInterface
+}
+
+func NewCustomFieldHandler(customFieldService application.CustomFieldServiceInterface) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+// customFieldInput is the request body for defining a custom field
+type customFieldInput struct {
+	Name    string           `json:"name" binding:"required"`
+	Type    domain.FieldType `json:"type" binding:"required"`
+	Options []string         `json:"options"`
+}
+
+// AddField handles defining a custom field on a project
+func (h *CustomFieldHandler) AddField(c *gin.Context) {
+	projectID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var input customFieldInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	field, err := h.customFieldService.AddField(projectID, domain.CustomField{Name: input.Name, Type: input.Type, Options: input.Options})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+// GetFields handles listing the custom fields of a project
+func (h *CustomFieldHandler) GetFields(c *gin.Context) {
+	projectID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	fields, err := h.customFieldService.GetFields(projectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, fields)
+}
+
+// DeleteField handles removing a custom field, and every value of it, from a project
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+	projectID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	fieldID, ok := idParam(c, "fieldId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid field ID"})
+		return
+	}
+
+	if err := h.customFieldService.DeleteField(projectID, fieldID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,10 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// CustomFieldHandlerInterface defines the contract for custom field handler operations.
+type CustomFieldHandlerInterface interface {
+	AddField(c *gin.Context)
+	GetFields(c *gin.Context)
+	DeleteField(c *gin.Context)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddField_Enum(t *testing.T) {
+	mockService := new(application.MockCustomFieldService)
+	handler := NewCustomFieldHandler(mockService)
+
+	input := domain.CustomField{Name: "Stage", Type: domain.FieldEnum, Options: []string{"draft", "final"}}
+	mockService.On("AddField", uint(1), input).Return(domain.CustomField{ID: 3, ProjectID: 1, Name: "Stage", Type: domain.FieldEnum, Options: []string{"draft", "final"}}, nil)
+
+	router := gin.Default()
+	router.POST("/projects/:id/fields", handler.AddField)
+
+	req, _ := http.NewRequest(http.MethodPost, "/projects/1/fields", bytes.NewBufferString(`{"name": "Stage", "type": "enum", "options": ["draft", "final"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteField_NotFound(t *testing.T) {
+	mockService := new(application.MockCustomFieldService)
+	handler := NewCustomFieldHandler(mockService)
+
+	mockService.On("DeleteField", uint(1), uint(9)).Return(domain.ErrCustomFieldNotFound)
+
+	router := gin.Default()
+	router.DELETE("/projects/:id/fields/:fieldId", handler.DeleteField)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/projects/1/fields/9", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
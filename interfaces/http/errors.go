@@ -0,0 +1,25 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+)
+
+// errorStatus maps an error returned by the application layer to an HTTP status code
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, application.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, application.ErrConflict), errors.Is(err, domain.ErrDuplicate):
+		return http.StatusConflict
+	case errors.Is(err, application.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
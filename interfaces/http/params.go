@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idParam parses a positive numeric ID from the named path parameter
+func idParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// userHeader names the request header identifying the user making the request
+const userHeader = "X-User-ID"
+
+// currentUser returns the user named by the X-User-ID header. A missing header
+// yields nil; a malformed one answers 400 and reports false.
+func currentUser(c *gin.Context) (*uint, bool) {
+	value := c.GetHeader(userHeader)
+	if value == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + userHeader + " header"})
+		return nil, false
+	}
+	userID := uint(id)
+	return &userID, true
+}
+
+// requireUser is currentUser for endpoints that only make sense for a known
+// user; a missing X-User-ID header answers 400 as well
+func requireUser(c *gin.Context) (uint, bool) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return 0, false
+	}
+	if userID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing " + userHeader + " header"})
+		return 0, false
+	}
+	return *userID, true
+}
+
+// timeQuery parses an optional RFC 3339 time from the named query parameter.
+// A missing parameter yields nil; a malformed one answers 400 and reports false.
+func timeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return nil, false
+	}
+	return &t, true
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectService application.ProjectServiceInterface
+}
+
+func NewProjectHandler(projectService application.ProjectServiceInterface) *ProjectHandler {
+	return &ProjectHandler{projectService: projectService}
+}
+
+// projectInput is the request body for creating and updating projects
+type projectInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// CreateProject handles creating a new project
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var input projectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.CreateProject(input.Name, input.Description)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// GetAllProjects handles fetching all projects with their task counts
+func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
+	projects, err := h.projectService.GetAllProjects()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProjectByID handles retrieving a project by its ID
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	project, err := h.projectService.GetProject(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject handles renaming a project and changing its description
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var input projectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(id, domain.Project{Name: input.Name, Description: input.Description})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject handles deleting an empty project
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := h.projectService.DeleteProject(id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetProjectTasks handles listing the tasks of a project
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	tasks, err := h.projectService.GetProjectTasks(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// moveToProjectInput is the request body for moving a task between projects; a null project_id removes it from its project
+type moveToProjectInput struct {
+	ProjectID *uint `json:"project_id"`
+}
+
+// MoveTaskToProject handles moving a task, with its subtasks, into another project
+func (h *ProjectHandler) MoveTaskToProject(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input moveToProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.projectService.As(actor).MoveTaskToProject(taskID, input.ProjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
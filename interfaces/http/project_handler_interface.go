@@ -0,0 +1,14 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// ProjectHandlerInterface defines the contract for project handler operations.
+type ProjectHandlerInterface interface {
+	CreateProject(c *gin.Context)
+	GetAllProjects(c *gin.Context)
+	GetProjectByID(c *gin.Context)
+	UpdateProject(c *gin.Context)
+	DeleteProject(c *gin.Context)
+	GetProjectTasks(c *gin.Context)
+	MoveTaskToProject(c *gin.Context)
+}
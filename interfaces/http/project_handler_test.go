@@ -0,0 +1,74 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProject(t *testing.T) {
+	mockService := new(application.MockProjectService)
+	handler := NewProjectHandler(mockService)
+
+	project := domain.Project{ID: 1, Name: "Home"}
+	mockService.On("CreateProject", "Home", "chores").Return(project, nil)
+
+	router := gin.Default()
+	router.POST("/projects", handler.CreateProject)
+
+	req, _ := http.NewRequest(http.MethodPost, "/projects", bytes.NewBufferString(`{"name": "Home", "description": "chores"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.Project
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, project.Name, response.Name)
+}
+
+func TestDeleteProject_Conflict(t *testing.T) {
+	mockService := new(application.MockProjectService)
+	handler := NewProjectHandler(mockService)
+
+	mockService.On("DeleteProject", uint(1)).Return(application.ErrConflict)
+
+	router := gin.Default()
+	router.DELETE("/projects/:id", handler.DeleteProject)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/projects/1", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestMoveTaskToProject_Null(t *testing.T) {
+	mockService := new(application.MockProjectService)
+	handler := NewProjectHandler(mockService)
+
+	mockService.On("MoveTaskToProject", uint(3), (*uint)(nil)).Return(domain.Task{ID: 3}, nil)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/project", handler.MoveTaskToProject)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/3/project", bytes.NewBufferString(`{"project_id": null}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "MoveTaskToProject", uint(3), (*uint)(nil))
+}
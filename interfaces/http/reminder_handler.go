@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	reminderService application.ReminderServiceInterface
+}
+
+func NewReminderHandler(reminderService application.ReminderServiceInterface) *ReminderHandler {
+	return &ReminderHandler{reminderService: reminderService}
+}
+
+// reminderInput is the request body for setting a reminder; exactly one of the fields is expected
+type reminderInput struct {
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+}
+
+// AddReminder handles setting a reminder on a task
+func (h *ReminderHandler) AddReminder(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input reminderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.reminderService.AddReminder(taskID, domain.Reminder{RemindAt: input.RemindAt, OffsetMinutes: input.OffsetMinutes})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// GetReminders handles listing the reminders of a task
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	reminders, err := h.reminderService.GetReminders(taskID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// DeleteReminder handles removing a reminder from a task
+func (h *ReminderHandler) DeleteReminder(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	id, ok := idParam(c, "reminderId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reminder ID"})
+		return
+	}
+
+	if err := h.reminderService.DeleteReminder(taskID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,10 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// ReminderHandlerInterface defines the contract for reminder handler operations.
+type ReminderHandlerInterface interface {
+	AddReminder(c *gin.Context)
+	GetReminders(c *gin.Context)
+	DeleteReminder(c *gin.Context)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddReminder_Offset(t *testing.T) {
+	mockService := new(application.MockReminderService)
+	handler := NewReminderHandler(mockService)
+
+	offset := 30
+	mockService.On("AddReminder", uint(1), domain.Reminder{OffsetMinutes: &offset}).Return(domain.Reminder{ID: 2, TaskID: 1, OffsetMinutes: &offset}, nil)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/reminders", handler.AddReminder)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/reminders", bytes.NewBufferString(`{"offset_minutes": 30}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteReminder_NotFound(t *testing.T) {
+	mockService := new(application.MockReminderService)
+	handler := NewReminderHandler(mockService)
+
+	mockService.On("DeleteReminder", uint(1), uint(9)).Return(domain.ErrReminderNotFound)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id/reminders/:reminderId", handler.DeleteReminder)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1/reminders/9", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService application.TagServiceInterface
+}
+
+func NewTagHandler(tagService application.TagServiceInterface) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// tagInput is the request body for creating, renaming and attaching tags
+type tagInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateTag handles creating a new tag
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var input tagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(input.Name)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// GetAllTags handles fetching all tags
+func (h *TagHandler) GetAllTags(c *gin.Context) {
+	tags, err := h.tagService.GetAllTags()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// GetTagByID handles retrieving a tag by its ID
+func (h *TagHandler) GetTagByID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	tag, err := h.tagService.GetTag(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// UpdateTag handles renaming a tag
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var input tagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagService.RenameTag(id, input.Name)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag handles deleting a tag
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	if err := h.tagService.DeleteTag(id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// AddTagToTask handles attaching a tag (created on demand) to a task
+func (h *TagHandler) AddTagToTask(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input tagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.tagService.As(actor).TagTask(taskID, input.Name)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveTagFromTask handles detaching a tag from a task
+func (h *TagHandler) RemoveTagFromTask(c *gin.Context) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	tagID, ok := idParam(c, "tagId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	actor, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.tagService.As(actor).UntagTask(taskID, tagID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
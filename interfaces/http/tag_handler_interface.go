@@ -0,0 +1,14 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// TagHandlerInterface defines the contract for tag handler operations.
+type TagHandlerInterface interface {
+	CreateTag(c *gin.Context)
+	GetAllTags(c *gin.Context)
+	GetTagByID(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+	AddTagToTask(c *gin.Context)
+	RemoveTagFromTask(c *gin.Context)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTag(t *testing.T) {
+	mockService := new(application.MockTagService)
+	handler := NewTagHandler(mockService)
+
+	tag := domain.Tag{ID: 1, Name: "backend"}
+	mockService.On("CreateTag", "backend").Return(tag, nil)
+
+	router := gin.Default()
+	router.POST("/tags", handler.CreateTag)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tags", bytes.NewBufferString(`{"name": "backend"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.Tag
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, tag.Name, response.Name)
+	mockService.AssertCalled(t, "CreateTag", "backend")
+}
+
+func TestCreateTag_Conflict(t *testing.T) {
+	mockService := new(application.MockTagService)
+	handler := NewTagHandler(mockService)
+
+	mockService.On("CreateTag", "backend").Return(domain.Tag{}, application.ErrConflict)
+
+	router := gin.Default()
+	router.POST("/tags", handler.CreateTag)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tags", bytes.NewBufferString(`{"name": "backend"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestRemoveTagFromTask(t *testing.T) {
+	mockService := new(application.MockTagService)
+	handler := NewTagHandler(mockService)
+
+	task := domain.Task{ID: 1, Title: "Test Task"}
+	mockService.On("UntagTask", uint(1), uint(2)).Return(task, nil)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id/tags/:tagId", handler.RemoveTagFromTask)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1/tags/2", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "UntagTask", uint(1), uint(2))
+}
+
+func TestRemoveTagFromTask_InvalidTagID(t *testing.T) {
+	mockService := new(application.MockTagService)
+	handler := NewTagHandler(mockService)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id/tags/:tagId", handler.RemoveTagFromTask)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1/tags/abc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "UntagTask", mock.Anything, mock.Anything)
+}
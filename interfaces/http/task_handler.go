@@ -1,8 +1,13 @@
 package http
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/krishnakumarkp/to-do/application"
 	"github.com/krishnakumarkp/to-do/domain"
@@ -24,9 +29,19 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	// The creator is whoever makes the request, never a value from the body
+	creatorID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	input.CreatorID = creatorID
 
-	task, err := h.taskService.CreateTask(input.Title, input.Description)
+	task, err := h.taskService.As(creatorID).CreateTask(input)
 	if err != nil {
+		if status := errorStatus(err); status != http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create task"})
 		return
 	}
@@ -34,9 +49,220 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	c.JSON(http.StatusOK, task)
 }
 
-// GetAllTasksHandler handles fetching all tasks
+// GetAllTasksHandler handles fetching tasks a page at a time. ?completed=,
+// ?created_after= and ?created_before= (RFC 3339) and ?q= filter the tasks,
+// ?sort=created_at|title|priority|position and ?order=asc|desc order them
+// (by priority, most important and then earliest due first unless asked
+// otherwise) and ?limit= sizes the page. When more tasks follow, the
+// X-Next-Cursor header and a rel="next" Link header carry the ?cursor= that
+// fetches the next page.
+//
+// Repeating ?tag= filters by tag (any of them, or all of them with
+// ?tag_mode=all), ?field[<id>]=<value> filters by custom field values and
+// ?sort=field:<id> orders by a custom field; these return every match at once,
+// ordered by the same sorts and orders as pages.
 func (h *TaskHandler) GetAllTasks(c *gin.Context) {
-	tasks, err := h.taskService.GetAllTasks()
+	if len(c.QueryArray("tag")) > 0 || len(c.QueryMap("field")) > 0 || strings.HasPrefix(c.Query("sort"), "field:") {
+		h.getFilteredTasks(c)
+		return
+	}
+
+	query := domain.TaskQuery{Sort: domain.TaskSort(c.Query("sort")), Text: c.Query("q")}
+	var ok bool
+	if query.Desc, ok = sortOrder(c, query.Sort); !ok {
+		return
+	}
+	if value := c.Query("completed"); value != "" {
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid completed flag"})
+			return
+		}
+		query.Completed = &completed
+	}
+	if query.CreatedAfter, ok = timeQuery(c, "created_after"); !ok {
+		return
+	}
+	if query.CreatedBefore, ok = timeQuery(c, "created_before"); !ok {
+		return
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		query.Limit = limit
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := domain.ParseTaskCursor(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		query.After = &cursor
+	}
+
+	page, err := h.taskService.QueryTasks(query)
+	if err != nil {
+		if status := errorStatus(err); status != http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	if page.Next != nil {
+		cursor := page.Next.Encode()
+		next := *c.Request.URL
+		params := next.Query()
+		params.Set("cursor", cursor)
+		next.RawQuery = params.Encode()
+		c.Header("X-Next-Cursor", cursor)
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+	c.JSON(http.StatusOK, page.Tasks)
+}
+
+// sortOrder reads ?order=, which defaults to descending for priority, so the
+// most important tasks come first, and to ascending otherwise
+func sortOrder(c *gin.Context, sort domain.TaskSort) (desc bool, ok bool) {
+	switch c.Query("order") {
+	case "":
+		return sort == domain.SortByPriority, true
+	case "asc":
+		return false, true
+	case "desc":
+		return true, true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order"})
+	return false, false
+}
+
+// pagingParams are the GetAllTasks parameters only paged listings understand
+var pagingParams = []string{"completed", "created_after", "created_before", "q", "limit", "cursor"}
+
+// getFilteredTasks handles the tag and custom field listings of GetAllTasks,
+// which return every matching task at once
+func (h *TaskHandler) getFilteredTasks(c *gin.Context) {
+	for _, name := range pagingParams {
+		if _, ok := c.GetQuery(name); ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": name + " cannot be combined with tag or custom field filters"})
+			return
+		}
+	}
+	query := domain.TaskQuery{Sort: domain.TaskSort(c.Query("sort"))}
+	var sortField uint
+	if id, ok := strings.CutPrefix(string(query.Sort), "field:"); ok {
+		n, err := strconv.Atoi(id)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort field"})
+			return
+		}
+		sortField = uint(n)
+	} else if query.Sort != "" && !query.Sort.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort: use created_at, title, priority, position or field:<id>"})
+		return
+	}
+	var ok bool
+	if query.Desc, ok = sortOrder(c, query.Sort); !ok {
+		return
+	}
+	fieldFilters := make(map[uint]string)
+	for id, value := range c.QueryMap("field") {
+		n, err := strconv.Atoi(id)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid field filter"})
+			return
+		}
+		fieldFilters[uint(n)] = value
+	}
+
+	var tasks []domain.Task
+	var err error
+	tags := c.QueryArray("tag")
+	switch {
+	case len(tags) > 0 && len(fieldFilters) > 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Filter by tags or by custom fields, not both"})
+		return
+	case len(tags) > 0:
+		mode := c.DefaultQuery("tag_mode", "any")
+		if mode != "any" && mode != "all" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag_mode"})
+			return
+		}
+		tasks, err = h.taskService.GetTasksByTags(tags, mode == "all")
+	case len(fieldFilters) > 0:
+		tasks, err = h.taskService.GetTasksByFieldValues(fieldFilters)
+	default:
+		tasks, err = h.taskService.GetAllTasks()
+	}
+	if err != nil {
+		if status := errorStatus(err); status != http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	// These results are sorted here rather than by the repository, in the
+	// same order the paged listing uses
+	switch {
+	case sortField != 0:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return domain.LessByField(tasks[i], tasks[j], sortField, query.Desc)
+		})
+	case query.Sort != "":
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return query.Compare(tasks[i], tasks[j]) < 0
+		})
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// SearchTasks handles full-text search over task titles and descriptions
+// with ?q=, returning up to ?limit= hits, most relevant first
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = n
+	}
+
+	hits, err := h.taskService.SearchTasks(c.Query("q"), limit)
+	if err != nil {
+		if status := errorStatus(err); status != http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, hits)
+}
+
+// GetOverdueTasks handles fetching open tasks that are past their deadline
+func (h *TaskHandler) GetOverdueTasks(c *gin.Context) {
+	tasks, err := h.taskService.GetOverdueTasks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetTasksDueToday handles fetching open tasks due today
+func (h *TaskHandler) GetTasksDueToday(c *gin.Context) {
+	tasks, err := h.taskService.GetTasksDueToday()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
 		return
@@ -45,6 +271,23 @@ func (h *TaskHandler) GetAllTasks(c *gin.Context) {
 	c.JSON(http.StatusOK, tasks)
 }
 
+// GetTasksDueSoon handles fetching open tasks due within the number of days given by ?days= (default 7)
+func (h *TaskHandler) GetTasksDueSoon(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days"})
+		return
+	}
+
+	tasks, err := h.taskService.GetTasksDueWithin(days)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
 // GetTaskByIDHandler handles retrieving a task by its ID
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -78,16 +321,21 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	updatedTask, err := h.taskService.UpdateTask(uint(id), task)
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	updatedTask, err := service.UpdateTask(uint(id), task)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, updatedTask)
 }
 
-// MarkTaskAsDoneHandler handles marking a task as done
+// MarkTaskAsDoneHandler handles marking a task as done; ?force=true also completes its open subtasks
 func (h *TaskHandler) MarkTaskAsDone(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -96,29 +344,933 @@ func (h *TaskHandler) MarkTaskAsDone(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.MarkTaskCompleted(uint(id))
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid force flag"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.MarkTaskCompleted(uint(id), force)
+	if err != nil {
+		switch status := errorStatus(err); status {
+		case http.StatusNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		case http.StatusInternalServerError:
+			log.Printf("Failed to complete task %d: %v", id, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete task"})
+		default:
+			c.JSON(status, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, task)
 }
 
-// DeleteTaskHandler handles deleting a task
-func (h *TaskHandler) DeleteTask(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil || id <= 0 {
+// statusInput is the request body for moving a task to another workflow status
+type statusInput struct {
+	Status domain.Status `json:"status" binding:"required"`
+}
+
+// SetTaskStatus handles moving a task along its status workflow
+func (h *TaskHandler) SetTaskStatus(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input statusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.SetTaskStatus(id, input.Status)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// GetSubtasks handles listing the direct subtasks of a task
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
 		return
 	}
 
-	err = h.taskService.DeleteTask(uint(id))
+	tasks, err := h.taskService.GetSubtasks(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	c.JSON(http.StatusOK, tasks)
+}
+
+// moveTaskInput is the request body for re-parenting a task; a null parent_id makes it top-level
+type moveTaskInput struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+// SetParent handles setting or clearing the parent task of a task
+func (h *TaskHandler) SetParent(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input moveTaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.SetParent(id, input.ParentID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// GetTaskSeries handles listing every occurrence of a task's recurring series
+func (h *TaskHandler) GetTaskSeries(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	tasks, err := h.taskService.GetSeries(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// recurrenceInput is the request body for changing a series' recurrence rule
+type recurrenceInput struct {
+	Recurrence string `json:"recurrence" binding:"required"`
+}
+
+// SetRecurrence handles changing the recurrence rule of a task's series
+func (h *TaskHandler) SetRecurrence(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input recurrenceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.SetRecurrence(id, input.Recurrence)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// StopRecurrence handles stopping a task's series so no further occurrences are spawned
+func (h *TaskHandler) StopRecurrence(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.SetRecurrence(id, "")
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// GetReadyTasks handles listing open tasks whose blockers are all complete
+func (h *TaskHandler) GetReadyTasks(c *gin.Context) {
+	tasks, err := h.taskService.GetReadyTasks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetMyTasks handles listing the tasks assigned to the user named by the X-User-ID header
+func (h *TaskHandler) GetMyTasks(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskService.GetTasksByAssignee(userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetUnassignedTasks handles listing the tasks nobody is assigned to
+func (h *TaskHandler) GetUnassignedTasks(c *gin.Context) {
+	tasks, err := h.taskService.GetUnassignedTasks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// assignInput is the request body for assigning a task
+type assignInput struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// AssignTask handles making a user responsible for a task
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input assignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.AssignTask(id, input.UserID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// UnassignTask handles removing a task's assignee
+func (h *TaskHandler) UnassignTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.UnassignTask(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// StartTimer handles starting the X-User-ID user's timer on a task
+func (h *TaskHandler) StartTimer(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.taskService.StartTimer(id, userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// StopTimer handles stopping the X-User-ID user's timer on a task
+func (h *TaskHandler) StopTimer(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.taskService.StopTimer(id, userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// timeEntryInput is the request body for logging time by hand
+type timeEntryInput struct {
+	StartedAt time.Time `json:"started_at" binding:"required"`
+	EndedAt   time.Time `json:"ended_at" binding:"required"`
+	Note      string    `json:"note"`
+}
+
+// AddTimeEntry handles logging time spent on a task by hand
+func (h *TaskHandler) AddTimeEntry(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var input timeEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.taskService.AddTimeEntry(id, domain.TimeEntry{
+		UserID:    userID,
+		StartedAt: input.StartedAt,
+		EndedAt:   &input.EndedAt,
+		Note:      input.Note,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetTimeEntries handles listing the time entries of a task
+func (h *TaskHandler) GetTimeEntries(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	entries, err := h.taskService.GetTimeEntries(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// DeleteTimeEntry handles removing a time entry from a task
+func (h *TaskHandler) DeleteTimeEntry(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	entryID, ok := idParam(c, "entryId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	if err := h.taskService.DeleteTimeEntry(id, entryID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetTaskTimeTotals handles comparing a task's estimate with the time tracked on it
+func (h *TaskHandler) GetTaskTimeTotals(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	totals, err := h.taskService.GetTaskTimeTotals(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// GetProjectTimeTotals handles comparing a project's estimates with the time tracked on its tasks
+func (h *TaskHandler) GetProjectTimeTotals(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	totals, err := h.taskService.GetProjectTimeTotals(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// GetBlockers handles listing the tasks a task is waiting on
+func (h *TaskHandler) GetBlockers(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	tasks, err := h.taskService.GetBlockers(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// blockerInput is the request body for making a task wait on another task
+type blockerInput struct {
+	BlockerID uint `json:"blocker_id" binding:"required"`
+}
+
+// AddBlocker handles making a task wait on another task
+func (h *TaskHandler) AddBlocker(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input blockerInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := h.taskService.AddBlocker(id, input.BlockerID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// RemoveBlocker handles removing a blocker from a task
+func (h *TaskHandler) RemoveBlocker(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	blockerID, ok := idParam(c, "blockerId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocker ID"})
+		return
+	}
+
+	if err := h.taskService.RemoveBlocker(id, blockerID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// checklistItemParams reads the task and checklist item IDs from the path, answering 400 when either is invalid
+func checklistItemParams(c *gin.Context) (uint, uint, bool) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	itemID, ok := idParam(c, "itemId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return 0, 0, false
+	}
+	return taskID, itemID, true
+}
+
+// checklistItemInput is the request body for adding a checklist item
+type checklistItemInput struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// AddChecklistItem handles appending an item to a task's checklist
+func (h *TaskHandler) AddChecklistItem(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input checklistItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.AddChecklistItem(id, input.Text)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// checklistPositionInput is the request body for reordering a checklist item
+type checklistPositionInput struct {
+	Position *int `json:"position" binding:"required"`
+}
+
+// MoveChecklistItem handles moving a checklist item to another position
+func (h *TaskHandler) MoveChecklistItem(c *gin.Context) {
+	id, itemID, ok := checklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var input checklistPositionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.MoveChecklistItem(id, itemID, *input.Position)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// ToggleChecklistItem handles checking or unchecking a checklist item
+func (h *TaskHandler) ToggleChecklistItem(c *gin.Context) {
+	id, itemID, ok := checklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.ToggleChecklistItem(id, itemID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveChecklistItem handles deleting a checklist item
+func (h *TaskHandler) RemoveChecklistItem(c *gin.Context) {
+	id, itemID, ok := checklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.RemoveChecklistItem(id, itemID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// fieldValueInput is the request body for setting a custom field value; its
+// type depends on the field
+type fieldValueInput struct {
+	Value any `json:"value" binding:"required"`
+}
+
+// fieldParams reads the task and custom field IDs from the path, answering 400 when either is invalid
+func fieldParams(c *gin.Context) (uint, uint, bool) {
+	taskID, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	fieldID, ok := idParam(c, "fieldId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid field ID"})
+		return 0, 0, false
+	}
+	return taskID, fieldID, true
+}
+
+// SetFieldValue handles setting a task's value for a custom field of its project
+func (h *TaskHandler) SetFieldValue(c *gin.Context) {
+	taskID, fieldID, ok := fieldParams(c)
+	if !ok {
+		return
+	}
+
+	var input fieldValueInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.SetFieldValue(taskID, fieldID, input.Value)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// ClearFieldValue handles removing a task's value for a custom field
+func (h *TaskHandler) ClearFieldValue(c *gin.Context) {
+	taskID, fieldID, ok := fieldParams(c)
+	if !ok {
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.ClearFieldValue(taskID, fieldID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// ReorderTask handles placing a task before or after another task in the manual order
+func (h *TaskHandler) ReorderTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var move domain.TaskMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.ReorderTask(id, move)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// ArchiveTask handles moving a completed task to the archive
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.ArchiveTask(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// UnarchiveTask handles taking a task out of the archive
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.UnarchiveTask(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+type bulkArchiveInput struct {
+	CompletedBefore time.Time `json:"completed_before" binding:"required"`
+}
+
+// ArchiveCompletedTasks handles archiving every task completed before a given time
+func (h *TaskHandler) ArchiveCompletedTasks(c *gin.Context) {
+	var input bulkArchiveInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	archived, err := service.ArchiveCompletedBefore(input.CompletedBefore)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"archived": archived})
+}
+
+// GetArchivedTasks handles searching the archive with an optional ?q= text
+func (h *TaskHandler) GetArchivedTasks(c *gin.Context) {
+	tasks, err := h.taskService.GetArchivedTasks(c.Query("q"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetTrash handles listing the tasks in the trash
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	tasks, err := h.taskService.GetTrash()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// RestoreTask handles taking a task back out of the trash
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.RestoreTask(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// PurgeTask handles permanently deleting a task in the trash
+func (h *TaskHandler) PurgeTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	if err := service.PurgeTask(id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// DeleteTaskHandler handles moving a task to the trash
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	err = service.DeleteTask(uint(id))
+	if err != nil {
+		if status := errorStatus(err); status != http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to delete task %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetTaskHistory handles listing the revisions of a task, oldest first
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	revisions, err := h.taskService.GetTaskHistory(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// RevertTask handles putting a task back the way it was right after one of its revisions
+func (h *TaskHandler) RevertTask(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	revisionID, ok := idParam(c, "revisionId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	service, ok := h.actingService(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.RevertTask(id, revisionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// actingService returns the task service scoped to the X-User-ID user, who is
+// recorded as the actor of the changes made through it
+func (h *TaskHandler) actingService(c *gin.Context) (application.TaskServiceInterface, bool) {
+	actor, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+	return h.taskService.As(actor), true
 }
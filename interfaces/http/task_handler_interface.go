@@ -6,8 +6,49 @@ import "github.com/gin-gonic/gin"
 type TaskHandlerInterface interface {
 	CreateTask(c *gin.Context)
 	GetAllTasks(c *gin.Context)
+	SearchTasks(c *gin.Context)
+	GetOverdueTasks(c *gin.Context)
+	GetTasksDueToday(c *gin.Context)
+	GetTasksDueSoon(c *gin.Context)
 	GetTaskByID(c *gin.Context)
 	UpdateTask(c *gin.Context)
 	MarkTaskAsDone(c *gin.Context)
+	SetTaskStatus(c *gin.Context)
+	GetSubtasks(c *gin.Context)
+	SetParent(c *gin.Context)
+	GetTaskSeries(c *gin.Context)
+	SetRecurrence(c *gin.Context)
+	StopRecurrence(c *gin.Context)
+	GetReadyTasks(c *gin.Context)
+	GetBlockers(c *gin.Context)
+	AddBlocker(c *gin.Context)
+	RemoveBlocker(c *gin.Context)
+	AddChecklistItem(c *gin.Context)
+	MoveChecklistItem(c *gin.Context)
+	ToggleChecklistItem(c *gin.Context)
+	RemoveChecklistItem(c *gin.Context)
+	GetMyTasks(c *gin.Context)
+	GetUnassignedTasks(c *gin.Context)
+	AssignTask(c *gin.Context)
+	UnassignTask(c *gin.Context)
+	StartTimer(c *gin.Context)
+	StopTimer(c *gin.Context)
+	AddTimeEntry(c *gin.Context)
+	GetTimeEntries(c *gin.Context)
+	DeleteTimeEntry(c *gin.Context)
+	GetTaskTimeTotals(c *gin.Context)
+	GetProjectTimeTotals(c *gin.Context)
+	SetFieldValue(c *gin.Context)
+	ClearFieldValue(c *gin.Context)
+	ReorderTask(c *gin.Context)
+	ArchiveTask(c *gin.Context)
+	UnarchiveTask(c *gin.Context)
+	ArchiveCompletedTasks(c *gin.Context)
+	GetArchivedTasks(c *gin.Context)
+	GetTrash(c *gin.Context)
+	RestoreTask(c *gin.Context)
+	PurgeTask(c *gin.Context)
+	GetTaskHistory(c *gin.Context)
+	RevertTask(c *gin.Context)
 	DeleteTask(c *gin.Context)
 }
@@ -3,11 +3,15 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/krishnakumarkp/to-do/application"
 	"github.com/krishnakumarkp/to-do/domain"
+	"github.com/krishnakumarkp/to-do/infrastructure"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -19,8 +23,8 @@ type MockTaskService struct {
 	mock.Mock
 }
 
-func (m *MockTaskService) CreateTask(title, description string) (domain.Task, error) {
-	args := m.Called(title, description)
+func (m *MockTaskService) CreateTask(task domain.Task) (domain.Task, error) {
+	args := m.Called(task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
@@ -34,27 +38,247 @@ func (m *MockTaskService) GetAllTasks() ([]domain.Task, error) {
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) QueryTasks(query domain.TaskQuery) (domain.TaskPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(domain.TaskPage), args.Error(1)
+}
+
+func (m *MockTaskService) SearchTasks(query string, limit int) ([]domain.SearchHit, error) {
+	args := m.Called(query, limit)
+	return args.Get(0).([]domain.SearchHit), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksByTags(names []string, matchAll bool) ([]domain.Task, error) {
+	args := m.Called(names, matchAll)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetOverdueTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksDueToday() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksDueWithin(days int) ([]domain.Task, error) {
+	args := m.Called(days)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
 func (m *MockTaskService) UpdateTask(id uint, task domain.Task) (domain.Task, error) {
 	args := m.Called(id, task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskService) MarkTaskCompleted(id uint) (domain.Task, error) {
+func (m *MockTaskService) MarkTaskCompleted(id uint, force bool) (domain.Task, error) {
+	args := m.Called(id, force)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetTaskStatus(id uint, status domain.Status) (domain.Task, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetSubtasks(id uint) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetParent(id uint, parentID *uint) (domain.Task, error) {
+	args := m.Called(id, parentID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetSeries(id uint) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) SetRecurrence(id uint, rule string) (domain.Task, error) {
+	args := m.Called(id, rule)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetBlockers(id uint) ([]domain.Task, error) {
 	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetReadyTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) AddBlocker(id, blockerID uint) ([]domain.Task, error) {
+	args := m.Called(id, blockerID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RemoveBlocker(id, blockerID uint) error {
+	args := m.Called(id, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) AddChecklistItem(taskID uint, text string) (domain.Task, error) {
+	args := m.Called(taskID, text)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) MoveChecklistItem(taskID, itemID uint, position int) (domain.Task, error) {
+	args := m.Called(taskID, itemID, position)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ToggleChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	args := m.Called(taskID, itemID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RemoveChecklistItem(taskID, itemID uint) (domain.Task, error) {
+	args := m.Called(taskID, itemID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) AssignTask(taskID, userID uint) (domain.Task, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UnassignTask(taskID uint) (domain.Task, error) {
+	args := m.Called(taskID)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) GetTasksByAssignee(userID uint) ([]domain.Task, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetUnassignedTasks() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) StartTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) StopTimer(taskID, userID uint) (domain.TimeEntry, error) {
+	args := m.Called(taskID, userID)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) AddTimeEntry(taskID uint, entry domain.TimeEntry) (domain.TimeEntry, error) {
+	args := m.Called(taskID, entry)
+	return args.Get(0).(domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) GetTimeEntries(taskID uint) ([]domain.TimeEntry, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTaskService) DeleteTimeEntry(taskID, entryID uint) error {
+	args := m.Called(taskID, entryID)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GetTaskTimeTotals(taskID uint) (domain.TimeTotals, error) {
+	args := m.Called(taskID)
+	return args.Get(0).(domain.TimeTotals), args.Error(1)
+}
+
+func (m *MockTaskService) GetProjectTimeTotals(projectID uint) (domain.TimeTotals, error) {
+	args := m.Called(projectID)
+	return args.Get(0).(domain.TimeTotals), args.Error(1)
+}
+
 func (m *MockTaskService) DeleteTask(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockTaskService) SetFieldValue(taskID, fieldID uint, value any) (domain.Task, error) {
+	args := m.Called(taskID, fieldID, value)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ClearFieldValue(taskID, fieldID uint) (domain.Task, error) {
+	args := m.Called(taskID, fieldID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTasksByFieldValues(filters map[uint]string) ([]domain.Task, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ReorderTask(id uint, move domain.TaskMove) (domain.Task, error) {
+	args := m.Called(id, move)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ArchiveTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) UnarchiveTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) ArchiveCompletedBefore(before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskService) GetArchivedTasks(query string) ([]domain.Task, error) {
+	args := m.Called(query)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) GetTrash() ([]domain.Task, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) RestoreTask(id uint) (domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskService) PurgeTask(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) GetTaskHistory(id uint) ([]domain.Revision, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Revision), args.Error(1)
+}
+
+func (m *MockTaskService) RevertTask(id, revisionID uint) (domain.Task, error) {
+	args := m.Called(id, revisionID)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+// As returns the mock itself, so expectations hold whichever user a handler acts as
+func (m *MockTaskService) As(actor *uint) application.TaskServiceInterface {
+	return m
+}
+
 func TestCreateTask(t *testing.T) {
 	mockService := new(MockTaskService)
 	handler := NewTaskHandler(mockService)
 
 	task := domain.Task{Title: "Test Task", Description: "Test Description"}
-	mockService.On("CreateTask", task.Title, task.Description).Return(task, nil)
+	mockService.On("CreateTask", task).Return(task, nil)
 
 	router := gin.Default()
 	router.POST("/tasks", handler.CreateTask)
@@ -71,46 +295,769 @@ func TestCreateTask(t *testing.T) {
 	var response map[string]interface{}
 	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
 	assert.Equal(t, task.Title, response["title"])
-	mockService.AssertCalled(t, "CreateTask", task.Title, task.Description)
+	mockService.AssertCalled(t, "CreateTask", task)
 }
 
-func TestGetTaskByID(t *testing.T) {
+func TestGetAllTasks_SortByPriority(t *testing.T) {
 	mockService := new(MockTaskService)
 	handler := NewTaskHandler(mockService)
 
-	task := domain.Task{ID: 1, Title: "Test Task"}
-	mockService.On("GetTask", uint(1)).Return(task, nil)
+	tasks := []domain.Task{{ID: 2, Title: "Outage", Priority: domain.PriorityUrgent}}
+	mockService.On("QueryTasks", domain.TaskQuery{Sort: domain.SortByPriority, Desc: true}).Return(domain.TaskPage{Tasks: tasks}, nil)
 
 	router := gin.Default()
-	router.GET("/tasks/:id", handler.GetTaskByID)
+	router.GET("/tasks", handler.GetAllTasks)
 
-	req, _ := http.NewRequest(http.MethodGet, "/tasks/1", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?sort=priority", nil)
 	recorder := httptest.NewRecorder()
 
 	router.ServeHTTP(recorder, req)
 
 	assert.Equal(t, http.StatusOK, recorder.Code)
 
-	var response domain.Task
+	var response []map[string]interface{}
 	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
-	assert.Equal(t, task.Title, response.Title)
-	mockService.AssertCalled(t, "GetTask", uint(1))
+	assert.Equal(t, "urgent", response[0]["priority"])
+	assert.Empty(t, recorder.Header().Get("X-Next-Cursor"))
+	mockService.AssertNotCalled(t, "GetAllTasks")
 }
 
-func TestDeleteTask(t *testing.T) {
+func TestGetAllTasks_SortByPriorityThenDueDate(t *testing.T) {
+	// Page through a real service and repository, so the cursors carry the
+	// due dates that order tasks of the same priority
+	repo := infrastructure.NewMockTaskRepository()
+	handler := NewTaskHandler(application.NewTaskService(repo))
+	soon := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 7)
+	for _, task := range []domain.Task{
+		{Title: "No due date", Priority: domain.PriorityHigh},
+		{Title: "Due later", Priority: domain.PriorityHigh, DueDate: &later},
+		{Title: "Docs", Priority: domain.PriorityLow, DueDate: &soon},
+		{Title: "Due soon", Priority: domain.PriorityHigh, DueDate: &soon},
+	} {
+		_, err := repo.Save(task)
+		assert.NoError(t, err)
+	}
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	var titles []string
+	url := "/tasks?sort=priority&limit=2"
+	for url != "" {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var response []map[string]interface{}
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+		for _, task := range response {
+			titles = append(titles, task["title"].(string))
+		}
+		url = ""
+		if cursor := recorder.Header().Get("X-Next-Cursor"); cursor != "" {
+			url = "/tasks?sort=priority&limit=2&cursor=" + cursor
+		}
+	}
+	assert.Equal(t, []string{"Due soon", "Due later", "No due date", "Docs"}, titles)
+}
+
+func TestGetAllTasks_NextCursor(t *testing.T) {
 	mockService := new(MockTaskService)
 	handler := NewTaskHandler(mockService)
 
-	mockService.On("DeleteTask", uint(1)).Return(nil)
+	completed := false
+	createdAfter := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	last := domain.Task{ID: 7, Title: "Write report", CreatedAt: time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)}
+	next := domain.CursorAt(last)
+	mockService.On("QueryTasks", domain.TaskQuery{Completed: &completed, CreatedAfter: &createdAfter, Text: "report", Limit: 1}).
+		Return(domain.TaskPage{Tasks: []domain.Task{last}, Next: &next}, nil)
 
 	router := gin.Default()
-	router.DELETE("/tasks/:id", handler.DeleteTask)
+	router.GET("/tasks", handler.GetAllTasks)
 
-	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?completed=false&created_after=2024-05-01T00:00:00Z&q=report&limit=1", nil)
 	recorder := httptest.NewRecorder()
 
 	router.ServeHTTP(recorder, req)
 
-	assert.Equal(t, http.StatusNoContent, recorder.Code)
-	mockService.AssertCalled(t, "DeleteTask", uint(1))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	cursor := recorder.Header().Get("X-Next-Cursor")
+	assert.Equal(t, next.Encode(), cursor)
+	assert.Contains(t, recorder.Header().Get("Link"), "cursor="+cursor)
+	assert.Contains(t, recorder.Header().Get("Link"), `rel="next"`)
+}
+
+func TestGetAllTasks_FollowsCursor(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	after := domain.TaskCursor{ID: 7, Title: "Write report"}
+	mockService.On("QueryTasks", domain.TaskQuery{Sort: domain.SortByTitle, After: &after}).Return(domain.TaskPage{Tasks: []domain.Task{}}, nil)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?sort=title&cursor="+after.Encode(), nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "[]", recorder.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllTasks_InvalidCursor(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?cursor=not-a-cursor", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "QueryTasks", mock.Anything)
+}
+
+func TestGetAllTasks_TagFilter(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	tasks := []domain.Task{{ID: 1, Title: "Page triage", Tags: []domain.Tag{{ID: 1, Name: "oncall"}}}}
+	mockService.On("GetTasksByTags", []string{"oncall", "backend"}, true).Return(tasks, nil)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?tag=oncall&tag=backend&tag_mode=all", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, tasks, response)
+	mockService.AssertCalled(t, "GetTasksByTags", []string{"oncall", "backend"}, true)
+}
+
+func TestGetAllTasks_FieldFilterSortedByField(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	low, high := 1.0, 8.0
+	tasks := []domain.Task{
+		{ID: 1, Title: "Small", FieldValues: []domain.FieldValue{{TaskID: 1, FieldID: 4, NumberValue: &low}}},
+		{ID: 2, Title: "Large", FieldValues: []domain.FieldValue{{TaskID: 2, FieldID: 4, NumberValue: &high}}},
+	}
+	mockService.On("GetTasksByFieldValues", map[uint]string{3: "final"}).Return(tasks, nil)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?field[3]=final&sort=field:4&order=desc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []map[string]interface{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, float64(2), response[0]["id"])
+	assert.Equal(t, float64(1), response[1]["id"])
+}
+
+func TestGetAllTasks_TagFilterSortedByTitleDescending(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	tasks := []domain.Task{{ID: 1, Title: "alpha"}, {ID: 2, Title: "Gamma"}, {ID: 3, Title: "beta"}}
+	mockService.On("GetTasksByTags", []string{"backend"}, false).Return(tasks, nil)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?tag=backend&sort=title&order=desc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, []uint{2, 3, 1}, []uint{response[0].ID, response[1].ID, response[2].ID})
+}
+
+func TestGetAllTasks_TagFilterSortedByPriorityAscending(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	tasks := []domain.Task{{ID: 1, Priority: domain.PriorityHigh}, {ID: 2, Priority: domain.PriorityLow}}
+	mockService.On("GetTasksByTags", []string{"backend"}, false).Return(tasks, nil)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?tag=backend&sort=priority&order=asc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, uint(2), response[0].ID)
+	assert.Equal(t, uint(1), response[1].ID)
+}
+
+func TestGetAllTasks_TagFilterUnknownSort(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.GET("/tasks", handler.GetAllTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?tag=backend&sort=due_date", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "created_at, title, priority, position")
+	mockService.AssertNotCalled(t, "GetTasksByTags", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_UnknownPriority(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.POST("/tasks", handler.CreateTask)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{"title": "Triage", "priority": "critical"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "CreateTask", mock.Anything)
+}
+
+func TestGetTasksDueSoon(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	tasks := []domain.Task{{ID: 1, Title: "Renew certificate"}}
+	mockService.On("GetTasksDueWithin", 3).Return(tasks, nil)
+
+	router := gin.Default()
+	router.GET("/tasks/due-soon", handler.GetTasksDueSoon)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/due-soon?days=3", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, tasks, response)
+	mockService.AssertCalled(t, "GetTasksDueWithin", 3)
+}
+
+func TestGetTasksDueSoon_InvalidDays(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.GET("/tasks/due-soon", handler.GetTasksDueSoon)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/due-soon?days=soon", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "GetTasksDueWithin", mock.Anything)
+}
+
+func TestGetTaskByID(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	task := domain.Task{ID: 1, Title: "Test Task"}
+	mockService.On("GetTask", uint(1)).Return(task, nil)
+
+	router := gin.Default()
+	router.GET("/tasks/:id", handler.GetTaskByID)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/1", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, task.Title, response.Title)
+	mockService.AssertCalled(t, "GetTask", uint(1))
+}
+
+func TestDeleteTask(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("DeleteTask", uint(1)).Return(nil)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id", handler.DeleteTask)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	mockService.AssertCalled(t, "DeleteTask", uint(1))
+}
+
+func TestMarkTaskAsDone_HidesInternalErrors(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+	mockService.On("MarkTaskCompleted", uint(1), false).Return(domain.Task{}, errors.New("Error 1205: Lock wait timeout exceeded"))
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/done", handler.MarkTaskAsDone)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/done", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.JSONEq(t, `{"error": "Failed to complete task"}`, recorder.Body.String())
+}
+
+func TestDeleteTask_Errors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		body   string
+	}{
+		{domain.ErrTaskNotFound, http.StatusNotFound, "task not found"},
+		{errors.New("connection refused"), http.StatusInternalServerError, "Failed to delete task"},
+	}
+	for _, tt := range tests {
+		mockService := new(MockTaskService)
+		handler := NewTaskHandler(mockService)
+		mockService.On("DeleteTask", uint(1)).Return(tt.err)
+
+		router := gin.Default()
+		router.DELETE("/tasks/:id", handler.DeleteTask)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/tasks/1", nil)
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tt.status, recorder.Code)
+		assert.JSONEq(t, `{"error": "`+tt.body+`"}`, recorder.Body.String())
+	}
+}
+
+func TestArchiveCompletedTasks(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("ArchiveCompletedBefore", before).Return(42, nil)
+
+	router := gin.Default()
+	router.POST("/tasks/archive", handler.ArchiveCompletedTasks)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/archive", bytes.NewBufferString(`{"completed_before":"2024-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"archived":42}`, recorder.Body.String())
+}
+
+func TestRestoreTask_NotInTrash(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("RestoreTask", uint(1)).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/restore", handler.RestoreTask)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/restore", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestAddBlocker_Cycle(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("AddBlocker", uint(12), uint(7)).Return([]domain.Task(nil), application.ErrConflict)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/blockers", handler.AddBlocker)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/12/blockers", bytes.NewBufferString(`{"blocker_id": 7}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	mockService.AssertCalled(t, "AddBlocker", uint(12), uint(7))
+}
+
+func TestSetTaskStatus(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	task := domain.Task{ID: 1, Title: "Release", Status: domain.StatusInProgress}
+	mockService.On("SetTaskStatus", uint(1), domain.StatusInProgress).Return(task, nil)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/status", handler.SetTaskStatus)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/status", bytes.NewBufferString(`{"status": "in_progress"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.Task
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, domain.StatusInProgress, response.Status)
+}
+
+func TestMarkTaskAsDone_OpenSubtasks(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("MarkTaskCompleted", uint(1), false).Return(domain.Task{}, application.ErrConflict)
+
+	router := gin.Default()
+	router.PATCH("/tasks/:id/done", handler.MarkTaskAsDone)
+
+	req, _ := http.NewRequest(http.MethodPatch, "/tasks/1/done", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestMarkTaskAsDone_Force(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	task := domain.Task{ID: 1, Title: "Release", Completed: true}
+	mockService.On("MarkTaskCompleted", uint(1), true).Return(task, nil)
+
+	router := gin.Default()
+	router.PATCH("/tasks/:id/done", handler.MarkTaskAsDone)
+
+	req, _ := http.NewRequest(http.MethodPatch, "/tasks/1/done?force=true", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "MarkTaskCompleted", uint(1), true)
+}
+
+func TestSetParent(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	parentID := uint(2)
+	task := domain.Task{ID: 1, Title: "Changelog", ParentID: &parentID}
+	mockService.On("SetParent", uint(1), &parentID).Return(task, nil)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/parent", handler.SetParent)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/parent", bytes.NewBufferString(`{"parent_id": 2}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "SetParent", uint(1), &parentID)
+}
+
+func TestSetRecurrence(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	task := domain.Task{ID: 1, Title: "Weekly report", Recurrence: "FREQ=WEEKLY;BYDAY=FR"}
+	mockService.On("SetRecurrence", uint(1), "FREQ=WEEKLY;BYDAY=FR").Return(task, nil)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/recurrence", handler.SetRecurrence)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/recurrence", bytes.NewBufferString(`{"recurrence": "FREQ=WEEKLY;BYDAY=FR"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "SetRecurrence", uint(1), "FREQ=WEEKLY;BYDAY=FR")
+}
+
+func TestStopRecurrence(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("SetRecurrence", uint(1), "").Return(domain.Task{ID: 1}, nil)
+
+	router := gin.Default()
+	router.DELETE("/tasks/:id/recurrence", handler.StopRecurrence)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/1/recurrence", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "SetRecurrence", uint(1), "")
+}
+
+func TestMoveChecklistItem_ToTop(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	task := domain.Task{ID: 1, Checklist: []domain.ChecklistItem{{ID: 3, Text: "c", Position: 0}}}
+	mockService.On("MoveChecklistItem", uint(1), uint(3), 0).Return(task, nil)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id/checklist/:itemId/position", handler.MoveChecklistItem)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1/checklist/3/position", bytes.NewBufferString(`{"position": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"checklist_progress":0`)
+	mockService.AssertCalled(t, "MoveChecklistItem", uint(1), uint(3), 0)
+}
+
+func TestToggleChecklistItem_NotFound(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("ToggleChecklistItem", uint(1), uint(9)).Return(domain.Task{}, domain.ErrChecklistItemNotFound)
+
+	router := gin.Default()
+	router.PATCH("/tasks/:id/checklist/:itemId/toggle", handler.ToggleChecklistItem)
+
+	req, _ := http.NewRequest(http.MethodPatch, "/tasks/1/checklist/9/toggle", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestCreateTask_SetsCreatorFromHeader(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	creatorID := uint(3)
+	mockService.On("CreateTask", domain.Task{Title: "Ship", CreatorID: &creatorID}).Return(domain.Task{ID: 1, Title: "Ship", CreatorID: &creatorID}, nil)
+
+	router := gin.Default()
+	router.POST("/tasks", handler.CreateTask)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{"title": "Ship", "creator_id": 8}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", "3")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetMyTasks_MissingHeader(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.GET("/tasks/mine", handler.GetMyTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/mine", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "GetTasksByAssignee", mock.Anything)
+}
+
+func TestGetMyTasks(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	userID := uint(3)
+	mockService.On("GetTasksByAssignee", uint(3)).Return([]domain.Task{{ID: 1, AssigneeID: &userID}}, nil)
+
+	router := gin.Default()
+	router.GET("/tasks/mine", handler.GetMyTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/mine", nil)
+	req.Header.Set("X-User-ID", "3")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertCalled(t, "GetTasksByAssignee", uint(3))
+}
+
+func TestStartTimer_MissingUser(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/timer/start", handler.StartTimer)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/timer/start", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "StartTimer", mock.Anything, mock.Anything)
+}
+
+func TestStartTimer_AlreadyRunning(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("StartTimer", uint(1), uint(3)).Return(domain.TimeEntry{}, application.ErrConflict)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/timer/start", handler.StartTimer)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/timer/start", nil)
+	req.Header.Set("X-User-ID", "3")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestRevertTask_UnknownRevision(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("RevertTask", uint(1), uint(9)).Return(domain.Task{}, domain.ErrRevisionNotFound)
+
+	router := gin.Default()
+	router.POST("/tasks/:id/history/:revisionId/revert", handler.RevertTask)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/1/history/9/revert", nil)
+	req.Header.Set("X-User-ID", "3")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestUpdateTask_InvalidUserHeader(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	router := gin.Default()
+	router.PUT("/tasks/:id", handler.UpdateTask)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/1", bytes.NewBufferString(`{"title": "Ship"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", "nobody")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+func TestSearchTasks(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	hits := []domain.SearchHit{{
+		Task:       domain.Task{ID: 8, Title: "Quarterly report"},
+		Score:      3.5,
+		Highlights: domain.SearchHighlights{Title: "Quarterly <mark>report</mark>"},
+	}}
+	mockService.On("SearchTasks", "report", 5).Return(hits, nil)
+
+	router := gin.Default()
+	router.GET("/search", handler.SearchTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/search?q=report&limit=5", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []domain.SearchHit
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, "Quarterly <mark>report</mark>", response[0].Highlights.Title)
+	assert.Equal(t, uint(8), response[0].Task.ID)
+}
+
+func TestSearchTasks_EmptyQuery(t *testing.T) {
+	mockService := new(MockTaskService)
+	handler := NewTaskHandler(mockService)
+
+	mockService.On("SearchTasks", "", 0).Return([]domain.SearchHit(nil), application.ErrInvalidInput)
+
+	router := gin.Default()
+	router.GET("/search", handler.SearchTasks)
+
+	req, _ := http.NewRequest(http.MethodGet, "/search", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
 }
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krishnakumarkp/to-do/application"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userService application.UserServiceInterface
+}
+
+func NewUserHandler(userService application.UserServiceInterface) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// userInput is the request body for creating users
+type userInput struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required"`
+}
+
+// CreateUser handles registering a new user
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var input userInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.CreateUser(input.Name, input.Email)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetAllUsers handles fetching all users
+func (h *UserHandler) GetAllUsers(c *gin.Context) {
+	users, err := h.userService.GetAllUsers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// GetUserByID handles retrieving a user by their ID
+func (h *UserHandler) GetUserByID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := h.userService.GetUser(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser handles deleting a user with no assigned tasks
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.userService.DeleteUser(id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,11 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// UserHandlerInterface defines the contract for user handler operations.
+type UserHandlerInterface interface {
+	CreateUser(c *gin.Context)
+	GetAllUsers(c *gin.Context)
+	GetUserByID(c *gin.Context)
+	DeleteUser(c *gin.Context)
+}
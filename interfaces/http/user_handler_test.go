@@ -0,0 +1,56 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krishnakumarkp/to-do/application"
+	"github.com/krishnakumarkp/to-do/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUser(t *testing.T) {
+	mockService := new(application.MockUserService)
+	handler := NewUserHandler(mockService)
+
+	user := domain.User{ID: 1, Name: "Ada", Email: "ada@example.com"}
+	mockService.On("CreateUser", "Ada", "ada@example.com").Return(user, nil)
+
+	router := gin.Default()
+	router.POST("/users", handler.CreateUser)
+
+	req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"name": "Ada", "email": "ada@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response domain.User
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Equal(t, user, response)
+}
+
+func TestCreateUser_DuplicateEmail(t *testing.T) {
+	mockService := new(application.MockUserService)
+	handler := NewUserHandler(mockService)
+
+	mockService.On("CreateUser", "Ada", "ada@example.com").Return(domain.User{}, application.ErrConflict)
+
+	router := gin.Default()
+	router.POST("/users", handler.CreateUser)
+
+	req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"name": "Ada", "email": "ada@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
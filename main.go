@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 	"github.com/krishnakumarkp/to-do/router"
 )
 
+// trashPurgeInterval is how often tasks past the trash retention are purged
+const trashPurgeInterval = time.Hour
+
 func main() {
 	// Load configuration
 	if err := config.LoadConfig(); err != nil {
@@ -29,19 +33,105 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Auto-migrate the Task model
-	if err := db.AutoMigrate(&domain.Task{}); err != nil {
+	// Auto-migrate the models
+	if err := db.AutoMigrate(&domain.Task{}, &domain.Tag{}, &domain.Project{}, &domain.TaskDependency{}, &domain.Comment{}, &domain.Attachment{}, &domain.ChecklistItem{}, &domain.User{}, &domain.TimeEntry{}, &domain.Reminder{}, &domain.CustomField{}, &domain.FieldValue{}, &domain.Revision{}); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := infrastructure.BackfillTaskStatus(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := infrastructure.CreateSearchIndexes(db); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
-	// Initialize repository, service, and handler
-	repo := infrastructure.NewMySQLTaskRepository(db)
+	// Initialize repositories, services, and handlers
+	var tagRepo domain.TagRepository = infrastructure.NewMySQLTagRepository(db)
+	projectRepo := infrastructure.NewMySQLProjectRepository(db)
+	commentRepo := infrastructure.NewMySQLCommentRepository(db)
+	attachmentRepo := infrastructure.NewMySQLAttachmentRepository(db)
+	userRepo := infrastructure.NewMySQLUserRepository(db)
+	reminderRepo := infrastructure.NewMySQLReminderRepository(db)
+	var customFieldRepo domain.CustomFieldRepository = infrastructure.NewMySQLCustomFieldRepository(db)
+	revisionRepo := infrastructure.NewMySQLRevisionRepository(db)
+	repo := infrastructure.NewTaskRepository(db)
+	timeEntryRepo := infrastructure.NewMySQLTimeEntryRepository(db)
+	var memoryRepo *infrastructure.MemoryTaskRepository
+	switch config.AppConfig.DBDriver {
+	case "bolt":
+		// Tasks live in the bolt file, everything else in the SQLite database
+		boltRepo, err := infrastructure.NewBoltTaskRepository(config.AppConfig.BoltPath, tagRepo, customFieldRepo)
+		if err != nil {
+			log.Fatalf("Failed to open task store: %v", err)
+		}
+		defer boltRepo.Close()
+		repo = boltRepo
+		timeEntryRepo = infrastructure.NewMySQLTimeEntryRepositoryFor(db, repo)
+	case "memory":
+		// Tasks live in memory, logged to MemoryDir, everything else in the SQLite database
+		memoryRepo, err = infrastructure.OpenMemoryTaskRepository(config.AppConfig.MemoryDir)
+		if err != nil {
+			log.Fatalf("Failed to open task store: %v", err)
+		}
+		defer memoryRepo.Close()
+		repo = memoryRepo
+		tagRepo = infrastructure.NewLinkedTagRepository(tagRepo, memoryRepo)
+		customFieldRepo = infrastructure.NewLinkedCustomFieldRepository(customFieldRepo, memoryRepo)
+		timeEntryRepo = infrastructure.NewMySQLTimeEntryRepositoryFor(db, repo)
+	}
+	blobStorage, err := infrastructure.NewLocalBlobStorage(config.AppConfig.AttachmentDir)
+	if err != nil {
+		log.Fatalf("Failed to open attachment storage: %v", err)
+	}
 	//repo := infrastructure.NewMockTaskRepository()
-	service := application.NewTaskService(repo)
+	//tagRepo := infrastructure.NewMockTagRepository(repo)
+	//projectRepo := infrastructure.NewMockProjectRepository()
+	//commentRepo := infrastructure.NewMockCommentRepository()
+	//attachmentRepo := infrastructure.NewMockAttachmentRepository()
+	//userRepo := infrastructure.NewMockUserRepository()
+	//timeEntryRepo := infrastructure.NewMockTimeEntryRepository(repo)
+	//reminderRepo := infrastructure.NewMockReminderRepository()
+	//customFieldRepo := infrastructure.NewMockCustomFieldRepository(repo)
+	//revisionRepo := infrastructure.NewMockRevisionRepository()
+	//blobStorage := infrastructure.NewMockBlobStorage()
+	attachmentLimits := application.DefaultAttachmentLimits
+	if config.AppConfig.AttachmentMaxSize > 0 {
+		attachmentLimits.MaxSize = config.AppConfig.AttachmentMaxSize
+	}
+	commentService := application.NewCommentService(commentRepo, repo)
+	attachmentService := application.NewAttachmentService(attachmentRepo, repo, blobStorage, attachmentLimits)
+	reminderService := application.NewReminderService(reminderRepo, repo, userRepo, newNotifier())
+	taskOptions := []application.TaskServiceOption{
+		application.WithProjects(projectRepo),
+		application.WithUsers(userRepo),
+		application.WithTimeTracking(timeEntryRepo),
+		application.WithCustomFields(customFieldRepo),
+		application.WithRevisions(revisionRepo),
+		application.WithDeleteHook(timeEntryRepo.DeleteByTask),
+		application.WithDeleteHook(reminderService.DeleteTaskReminders),
+		application.WithDueDateHook(reminderService.RescheduleTask),
+		application.WithDeleteHook(commentService.DeleteTaskComments),
+		application.WithDeleteHook(attachmentService.DeleteTaskAttachments),
+		application.WithDeleteHook(revisionRepo.DeleteByTask),
+	}
+	if config.AppConfig.ChecklistAutoComplete {
+		taskOptions = append(taskOptions, application.WithChecklistAutoComplete())
+	}
+	service := application.NewTaskService(repo, taskOptions...)
+	tagService := application.NewTagService(tagRepo, repo, application.WithTagRevisions(revisionRepo))
+	projectService := application.NewProjectService(projectRepo, repo, application.WithProjectRevisions(revisionRepo))
+	userService := application.NewUserService(userRepo, repo)
+	customFieldService := application.NewCustomFieldService(customFieldRepo, projectRepo)
 	taskHandler := httpHandler.NewTaskHandler(service)
+	tagHandler := httpHandler.NewTagHandler(tagService)
+	projectHandler := httpHandler.NewProjectHandler(projectService)
+	commentHandler := httpHandler.NewCommentHandler(commentService)
+	attachmentHandler := httpHandler.NewAttachmentHandler(attachmentService)
+	userHandler := httpHandler.NewUserHandler(userService)
+	reminderHandler := httpHandler.NewReminderHandler(reminderService)
+	customFieldHandler := httpHandler.NewCustomFieldHandler(customFieldService)
 
 	// Set up the router using the router package
-	router := router.SetupRouter(taskHandler)
+	router := router.SetupRouter(taskHandler, tagHandler, projectHandler, commentHandler, attachmentHandler, userHandler, reminderHandler, customFieldHandler)
 
 	// Create the HTTP server
 	srv := &http.Server{
@@ -57,6 +147,34 @@ func main() {
 		}
 	}()
 
+	// Start the reminder scheduler, the trash purge and the task snapshots; they stop when schedulerCtx is cancelled
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	var schedulers sync.WaitGroup
+	schedulers.Add(1)
+	go func() {
+		defer schedulers.Done()
+		reminderService.Run(schedulerCtx, config.AppConfig.ReminderInterval)
+	}()
+	if config.AppConfig.TrashRetention > 0 {
+		schedulers.Add(1)
+		go func() {
+			defer schedulers.Done()
+			service.RunTrashPurge(schedulerCtx, config.AppConfig.TrashRetention, trashPurgeInterval)
+		}()
+	}
+	if memoryRepo != nil {
+		schedulers.Add(1)
+		go func() {
+			defer schedulers.Done()
+			memoryRepo.RunSnapshots(schedulerCtx, config.AppConfig.SnapshotInterval)
+		}()
+	}
+	schedulerDone := make(chan struct{})
+	go func() {
+		schedulers.Wait()
+		close(schedulerDone)
+	}()
+
 	// Graceful shutdown: listen for SIGINT and SIGTERM signals
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
@@ -74,6 +192,26 @@ func main() {
 		log.Fatalf("Server shutdown failed: %v", err)
 	}
 
+	// Stop the schedulers, letting a delivery in progress finish or time out
+	stopScheduler()
+	select {
+	case <-schedulerDone:
+	case <-ctx.Done():
+		log.Println("Schedulers did not stop in time")
+	}
+
 	// Perform any cleanup here (like closing DB connections, etc.)
 	log.Println("Server stopped gracefully.")
 }
+
+// newNotifier builds the reminder notifier selected in the configuration
+func newNotifier() domain.Notifier {
+	switch config.AppConfig.ReminderNotifier {
+	case "webhook":
+		return infrastructure.NewWebhookNotifier(config.AppConfig.ReminderWebhookURL)
+	case "smtp":
+		return infrastructure.NewSMTPNotifier(config.AppConfig.SMTPAddr, config.AppConfig.SMTPFrom, config.AppConfig.SMTPTo)
+	default:
+		return infrastructure.NewLogNotifier(nil)
+	}
+}
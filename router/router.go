@@ -7,16 +7,104 @@ import (
 )
 
 // SetupRouter initializes and returns the Gin router with all the routes
-func SetupRouter(taskHandler http.TaskHandlerInterface) *gin.Engine {
+func SetupRouter(taskHandler http.TaskHandlerInterface, tagHandler http.TagHandlerInterface, projectHandler http.ProjectHandlerInterface, commentHandler http.CommentHandlerInterface, attachmentHandler http.AttachmentHandlerInterface, userHandler http.UserHandlerInterface, reminderHandler http.ReminderHandlerInterface, customFieldHandler http.CustomFieldHandlerInterface) *gin.Engine {
 	router := gin.Default()
 
 	// Define routes
-	router.POST("/tasks", taskHandler.CreateTask)               // Route to create a task
-	router.GET("/tasks", taskHandler.GetAllTasks)               // Route to get all tasks
-	router.GET("/tasks/:id", taskHandler.GetTaskByID)           // Route to get task by ID
-	router.PUT("/tasks/:id", taskHandler.UpdateTask)            // Route to update task by ID
-	router.PATCH("/tasks/:id/done", taskHandler.MarkTaskAsDone) // Route to mark task as done
-	router.DELETE("/tasks/:id", taskHandler.DeleteTask)         // Route to delete
+	router.POST("/tasks", taskHandler.CreateTask)                                        // Route to create a task
+	router.GET("/tasks", taskHandler.GetAllTasks)                                        // Route to get all tasks
+	router.GET("/tasks/overdue", taskHandler.GetOverdueTasks)                            // Route to get overdue tasks
+	router.GET("/search", taskHandler.SearchTasks)                                       // Route to search task titles and descriptions with ?q=
+	router.GET("/tasks/due-today", taskHandler.GetTasksDueToday)                         // Route to get tasks due today
+	router.GET("/tasks/due-soon", taskHandler.GetTasksDueSoon)                           // Route to get tasks due within ?days=N
+	router.GET("/tasks/ready", taskHandler.GetReadyTasks)                                // Route to get open tasks with no open blockers
+	router.GET("/tasks/mine", taskHandler.GetMyTasks)                                    // Route to get the tasks assigned to the X-User-ID user
+	router.GET("/tasks/unassigned", taskHandler.GetUnassignedTasks)                      // Route to get tasks nobody is assigned to
+	router.GET("/tasks/archived", taskHandler.GetArchivedTasks)                          // Route to search archived tasks with ?q=
+	router.POST("/tasks/archive", taskHandler.ArchiveCompletedTasks)                     // Route to archive tasks completed before a date
+	router.GET("/tasks/:id", taskHandler.GetTaskByID)                                    // Route to get task by ID
+	router.PUT("/tasks/:id", taskHandler.UpdateTask)                                     // Route to update task by ID
+	router.PATCH("/tasks/:id/done", taskHandler.MarkTaskAsDone)                          // Route to mark task as done
+	router.PUT("/tasks/:id/status", taskHandler.SetTaskStatus)                           // Route to move a task to another status
+	router.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)                           // Route to list subtasks
+	router.PUT("/tasks/:id/parent", taskHandler.SetParent)                               // Route to move a task under another parent
+	router.GET("/tasks/:id/series", taskHandler.GetTaskSeries)                           // Route to list a recurring series
+	router.PUT("/tasks/:id/recurrence", taskHandler.SetRecurrence)                       // Route to change a series' recurrence rule
+	router.DELETE("/tasks/:id/recurrence", taskHandler.StopRecurrence)                   // Route to stop a recurring series
+	router.GET("/tasks/:id/blockers", taskHandler.GetBlockers)                           // Route to list the tasks a task waits on
+	router.POST("/tasks/:id/blockers", taskHandler.AddBlocker)                           // Route to make a task wait on another
+	router.DELETE("/tasks/:id/blockers/:blockerId", taskHandler.RemoveBlocker)           // Route to remove a blocker
+	router.POST("/tasks/:id/checklist", taskHandler.AddChecklistItem)                    // Route to add a checklist item
+	router.PUT("/tasks/:id/checklist/:itemId/position", taskHandler.MoveChecklistItem)   // Route to reorder a checklist item
+	router.PATCH("/tasks/:id/checklist/:itemId/toggle", taskHandler.ToggleChecklistItem) // Route to check or uncheck a checklist item
+	router.DELETE("/tasks/:id/checklist/:itemId", taskHandler.RemoveChecklistItem)       // Route to remove a checklist item
+	router.PUT("/tasks/:id/assignee", taskHandler.AssignTask)                            // Route to assign a task to a user
+	router.DELETE("/tasks/:id/assignee", taskHandler.UnassignTask)                       // Route to unassign a task
+	router.POST("/tasks/:id/timer/start", taskHandler.StartTimer)                        // Route to start the X-User-ID user's timer
+	router.POST("/tasks/:id/timer/stop", taskHandler.StopTimer)                          // Route to stop the X-User-ID user's timer
+	router.POST("/tasks/:id/time-entries", taskHandler.AddTimeEntry)                     // Route to log time by hand
+	router.GET("/tasks/:id/time-entries", taskHandler.GetTimeEntries)                    // Route to list a task's time entries
+	router.DELETE("/tasks/:id/time-entries/:entryId", taskHandler.DeleteTimeEntry)       // Route to delete a time entry
+	router.GET("/tasks/:id/time", taskHandler.GetTaskTimeTotals)                         // Route to compare a task's estimate with tracked time
+	router.GET("/projects/:id/time", taskHandler.GetProjectTimeTotals)                   // Route to compare a project's estimates with tracked time
+	router.PUT("/tasks/:id/fields/:fieldId", taskHandler.SetFieldValue)                  // Route to set a custom field value on a task
+	router.DELETE("/tasks/:id/fields/:fieldId", taskHandler.ClearFieldValue)             // Route to clear a custom field value
+	router.POST("/tasks/:id/move", taskHandler.ReorderTask)                              // Route to place a task before or after another
+	router.PUT("/tasks/:id/archive", taskHandler.ArchiveTask)                            // Route to archive a completed task
+	router.DELETE("/tasks/:id/archive", taskHandler.UnarchiveTask)                       // Route to take a task out of the archive
+	router.POST("/tasks/:id/restore", taskHandler.RestoreTask)                           // Route to take a task out of the trash
+	router.GET("/tasks/:id/history", taskHandler.GetTaskHistory)                         // Route to list a task's revisions
+	router.POST("/tasks/:id/history/:revisionId/revert", taskHandler.RevertTask)         // Route to revert a task to one of its revisions
+	router.DELETE("/tasks/:id", taskHandler.DeleteTask)                                  // Route to move a task to the trash
+	router.GET("/trash", taskHandler.GetTrash)                                           // Route to list the tasks in the trash
+	router.DELETE("/trash/:id", taskHandler.PurgeTask)                                   // Route to permanently delete a task in the trash
+
+	// Tag routes
+	router.POST("/tags", tagHandler.CreateTag)                            // Route to create a tag
+	router.GET("/tags", tagHandler.GetAllTags)                            // Route to get all tags
+	router.GET("/tags/:id", tagHandler.GetTagByID)                        // Route to get tag by ID
+	router.PUT("/tags/:id", tagHandler.UpdateTag)                         // Route to rename a tag
+	router.DELETE("/tags/:id", tagHandler.DeleteTag)                      // Route to delete a tag
+	router.POST("/tasks/:id/tags", tagHandler.AddTagToTask)               // Route to tag a task
+	router.DELETE("/tasks/:id/tags/:tagId", tagHandler.RemoveTagFromTask) // Route to untag a task
+
+	// Project routes
+	router.POST("/projects", projectHandler.CreateProject)             // Route to create a project
+	router.GET("/projects", projectHandler.GetAllProjects)             // Route to get all projects with task counts
+	router.GET("/projects/:id", projectHandler.GetProjectByID)         // Route to get project by ID
+	router.PUT("/projects/:id", projectHandler.UpdateProject)          // Route to update a project
+	router.DELETE("/projects/:id", projectHandler.DeleteProject)       // Route to delete an empty project
+	router.GET("/projects/:id/tasks", projectHandler.GetProjectTasks)  // Route to list a project's tasks
+	router.PUT("/tasks/:id/project", projectHandler.MoveTaskToProject) // Route to move a task into a project
+
+	// Custom field routes
+	router.POST("/projects/:id/fields", customFieldHandler.AddField)               // Route to define a custom field on a project
+	router.GET("/projects/:id/fields", customFieldHandler.GetFields)               // Route to list a project's custom fields
+	router.DELETE("/projects/:id/fields/:fieldId", customFieldHandler.DeleteField) // Route to delete a custom field and its values
+
+	// Comment routes
+	router.POST("/tasks/:id/comments", commentHandler.AddComment)                 // Route to comment on a task
+	router.GET("/tasks/:id/comments", commentHandler.GetComments)                 // Route to list a task's comments
+	router.GET("/tasks/:id/comments/:commentId", commentHandler.GetComment)       // Route to get a comment
+	router.PUT("/tasks/:id/comments/:commentId", commentHandler.UpdateComment)    // Route to edit a comment
+	router.DELETE("/tasks/:id/comments/:commentId", commentHandler.DeleteComment) // Route to delete a comment
+
+	// Attachment routes
+	router.POST("/tasks/:id/attachments", attachmentHandler.UploadAttachment)                 // Route to upload a file to a task
+	router.GET("/tasks/:id/attachments", attachmentHandler.GetAttachments)                    // Route to list a task's attachments
+	router.GET("/tasks/:id/attachments/:attachmentId", attachmentHandler.DownloadAttachment)  // Route to download an attachment
+	router.DELETE("/tasks/:id/attachments/:attachmentId", attachmentHandler.DeleteAttachment) // Route to delete an attachment
+
+	// Reminder routes
+	router.POST("/tasks/:id/reminders", reminderHandler.AddReminder)                  // Route to set a reminder on a task
+	router.GET("/tasks/:id/reminders", reminderHandler.GetReminders)                  // Route to list a task's reminders
+	router.DELETE("/tasks/:id/reminders/:reminderId", reminderHandler.DeleteReminder) // Route to delete a reminder
+
+	// User routes
+	router.POST("/users", userHandler.CreateUser)       // Route to create a user
+	router.GET("/users", userHandler.GetAllUsers)       // Route to get all users
+	router.GET("/users/:id", userHandler.GetUserByID)   // Route to get user by ID
+	router.DELETE("/users/:id", userHandler.DeleteUser) // Route to delete a user with no assigned tasks
 
 	return router
 }
@@ -25,6 +25,26 @@ func (m *MockTaskHandler) GetAllTasks(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "All tasks"})
 }
 
+func (m *MockTaskHandler) SearchTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Search results"})
+}
+
+func (m *MockTaskHandler) GetOverdueTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Overdue tasks"})
+}
+
+func (m *MockTaskHandler) GetTasksDueToday(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tasks due today"})
+}
+
+func (m *MockTaskHandler) GetTasksDueSoon(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tasks due soon"})
+}
+
 func (m *MockTaskHandler) GetTaskByID(c *gin.Context) {
 	m.Called(c)
 	c.JSON(http.StatusOK, gin.H{"message": "Task by ID"})
@@ -40,15 +60,400 @@ func (m *MockTaskHandler) MarkTaskAsDone(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Task marked as done"})
 }
 
+func (m *MockTaskHandler) SetTaskStatus(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task status changed"})
+}
+
+func (m *MockTaskHandler) GetSubtasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Subtasks"})
+}
+
+func (m *MockTaskHandler) SetParent(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task moved"})
+}
+
+func (m *MockTaskHandler) GetTaskSeries(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task series"})
+}
+
+func (m *MockTaskHandler) SetRecurrence(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Recurrence set"})
+}
+
+func (m *MockTaskHandler) StopRecurrence(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Recurrence stopped"})
+}
+
+func (m *MockTaskHandler) GetReadyTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Ready tasks"})
+}
+
+func (m *MockTaskHandler) GetBlockers(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Blockers"})
+}
+
+func (m *MockTaskHandler) AddBlocker(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Blocker added"})
+}
+
+func (m *MockTaskHandler) RemoveBlocker(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (m *MockTaskHandler) AddChecklistItem(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item added"})
+}
+
+func (m *MockTaskHandler) MoveChecklistItem(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item moved"})
+}
+
+func (m *MockTaskHandler) ToggleChecklistItem(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item toggled"})
+}
+
+func (m *MockTaskHandler) RemoveChecklistItem(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item removed"})
+}
+
+func (m *MockTaskHandler) GetMyTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "My tasks"})
+}
+
+func (m *MockTaskHandler) GetUnassignedTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Unassigned tasks"})
+}
+
+func (m *MockTaskHandler) AssignTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task assigned"})
+}
+
+func (m *MockTaskHandler) UnassignTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task unassigned"})
+}
+
+func (m *MockTaskHandler) StartTimer(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Timer started"})
+}
+
+func (m *MockTaskHandler) StopTimer(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Timer stopped"})
+}
+
+func (m *MockTaskHandler) AddTimeEntry(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry added"})
+}
+
+func (m *MockTaskHandler) GetTimeEntries(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All time entries"})
+}
+
+func (m *MockTaskHandler) DeleteTimeEntry(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (m *MockTaskHandler) GetTaskTimeTotals(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task time totals"})
+}
+
+func (m *MockTaskHandler) GetProjectTimeTotals(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Project time totals"})
+}
+
+func (m *MockTaskHandler) SetFieldValue(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Field value set"})
+}
+
+func (m *MockTaskHandler) ClearFieldValue(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Field value cleared"})
+}
+
+func (m *MockTaskHandler) ReorderTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task moved"})
+}
+
+func (m *MockTaskHandler) ArchiveTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task archived"})
+}
+
+func (m *MockTaskHandler) UnarchiveTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task unarchived"})
+}
+
+func (m *MockTaskHandler) ArchiveCompletedTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tasks archived"})
+}
+
+func (m *MockTaskHandler) GetArchivedTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Archived tasks"})
+}
+
+func (m *MockTaskHandler) GetTrash(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Trash"})
+}
+
+func (m *MockTaskHandler) RestoreTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task restored"})
+}
+
+func (m *MockTaskHandler) GetTaskHistory(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task history"})
+}
+
+func (m *MockTaskHandler) RevertTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task reverted"})
+}
+
+func (m *MockTaskHandler) PurgeTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
 func (m *MockTaskHandler) DeleteTask(c *gin.Context) {
 	m.Called(c)
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// MockTagHandler is a mock implementation of the TagHandler
+type MockTagHandler struct {
+	mock.Mock
+}
+
+func (m *MockTagHandler) CreateTag(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tag created"})
+}
+
+func (m *MockTagHandler) GetAllTags(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All tags"})
+}
+
+func (m *MockTagHandler) GetTagByID(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tag by ID"})
+}
+
+func (m *MockTagHandler) UpdateTag(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Tag updated"})
+}
+
+func (m *MockTagHandler) DeleteTag(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (m *MockTagHandler) AddTagToTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task tagged"})
+}
+
+func (m *MockTagHandler) RemoveTagFromTask(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task untagged"})
+}
+
+// MockProjectHandler is a mock implementation of the ProjectHandler
+type MockProjectHandler struct {
+	mock.Mock
+}
+
+func (m *MockProjectHandler) CreateProject(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Project created"})
+}
+
+func (m *MockProjectHandler) GetAllProjects(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All projects"})
+}
+
+func (m *MockProjectHandler) GetProjectByID(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Project by ID"})
+}
+
+func (m *MockProjectHandler) UpdateProject(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Project updated"})
+}
+
+func (m *MockProjectHandler) DeleteProject(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (m *MockProjectHandler) GetProjectTasks(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Project tasks"})
+}
+
+func (m *MockProjectHandler) MoveTaskToProject(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Task moved to project"})
+}
+
+// MockCommentHandler is a mock implementation of the CommentHandler
+type MockCommentHandler struct {
+	mock.Mock
+}
+
+func (m *MockCommentHandler) AddComment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Comment added"})
+}
+
+func (m *MockCommentHandler) GetComments(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All comments"})
+}
+
+func (m *MockCommentHandler) GetComment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Comment by ID"})
+}
+
+func (m *MockCommentHandler) UpdateComment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Comment updated"})
+}
+
+func (m *MockCommentHandler) DeleteComment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MockAttachmentHandler is a mock implementation of the AttachmentHandler
+type MockAttachmentHandler struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentHandler) UploadAttachment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment uploaded"})
+}
+
+func (m *MockAttachmentHandler) GetAttachments(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All attachments"})
+}
+
+func (m *MockAttachmentHandler) DownloadAttachment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment content"})
+}
+
+func (m *MockAttachmentHandler) DeleteAttachment(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MockUserHandler is a mock implementation of the UserHandler
+type MockUserHandler struct {
+	mock.Mock
+}
+
+func (m *MockUserHandler) CreateUser(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "User created"})
+}
+
+func (m *MockUserHandler) GetAllUsers(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All users"})
+}
+
+func (m *MockUserHandler) GetUserByID(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "User details"})
+}
+
+func (m *MockUserHandler) DeleteUser(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MockReminderHandler is a mock implementation of the ReminderHandler
+type MockReminderHandler struct {
+	mock.Mock
+}
+
+func (m *MockReminderHandler) AddReminder(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Reminder added"})
+}
+
+func (m *MockReminderHandler) GetReminders(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All reminders"})
+}
+
+func (m *MockReminderHandler) DeleteReminder(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MockCustomFieldHandler is a mock implementation of the CustomFieldHandler
+type MockCustomFieldHandler struct {
+	mock.Mock
+}
+
+func (m *MockCustomFieldHandler) AddField(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Field added"})
+}
+
+func (m *MockCustomFieldHandler) GetFields(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusOK, gin.H{"message": "All fields"})
+}
+
+func (m *MockCustomFieldHandler) DeleteField(c *gin.Context) {
+	m.Called(c)
+	c.JSON(http.StatusNoContent, nil)
+}
+
 func TestSetupRouter(t *testing.T) {
 	// Create a mock task handler
 	mockHandler := new(MockTaskHandler)
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, new(MockTagHandler), new(MockProjectHandler), new(MockCommentHandler), new(MockAttachmentHandler), new(MockUserHandler), new(MockReminderHandler), new(MockCustomFieldHandler))
 
 	// Define test cases
 	tests := []struct {
@@ -59,10 +464,51 @@ func TestSetupRouter(t *testing.T) {
 	}{
 		{"POST", "/tasks", http.StatusOK, "CreateTask"},
 		{"GET", "/tasks", http.StatusOK, "GetAllTasks"},
+		{"GET", "/search", http.StatusOK, "SearchTasks"},
+		{"GET", "/tasks/overdue", http.StatusOK, "GetOverdueTasks"},
+		{"GET", "/tasks/due-today", http.StatusOK, "GetTasksDueToday"},
+		{"GET", "/tasks/due-soon", http.StatusOK, "GetTasksDueSoon"},
 		{"GET", "/tasks/1", http.StatusOK, "GetTaskByID"},
 		{"PUT", "/tasks/1", http.StatusOK, "UpdateTask"},
 		{"PATCH", "/tasks/1/done", http.StatusOK, "MarkTaskAsDone"},
+		{"PUT", "/tasks/1/status", http.StatusOK, "SetTaskStatus"},
+		{"GET", "/tasks/ready", http.StatusOK, "GetReadyTasks"},
+		{"GET", "/tasks/1/blockers", http.StatusOK, "GetBlockers"},
+		{"POST", "/tasks/1/blockers", http.StatusOK, "AddBlocker"},
+		{"DELETE", "/tasks/1/blockers/2", http.StatusNoContent, "RemoveBlocker"},
+		{"POST", "/tasks/1/checklist", http.StatusOK, "AddChecklistItem"},
+		{"PUT", "/tasks/1/checklist/2/position", http.StatusOK, "MoveChecklistItem"},
+		{"PATCH", "/tasks/1/checklist/2/toggle", http.StatusOK, "ToggleChecklistItem"},
+		{"DELETE", "/tasks/1/checklist/2", http.StatusOK, "RemoveChecklistItem"},
+		{"GET", "/tasks/mine", http.StatusOK, "GetMyTasks"},
+		{"GET", "/tasks/unassigned", http.StatusOK, "GetUnassignedTasks"},
+		{"PUT", "/tasks/1/assignee", http.StatusOK, "AssignTask"},
+		{"DELETE", "/tasks/1/assignee", http.StatusOK, "UnassignTask"},
+		{"POST", "/tasks/1/timer/start", http.StatusOK, "StartTimer"},
+		{"POST", "/tasks/1/timer/stop", http.StatusOK, "StopTimer"},
+		{"POST", "/tasks/1/time-entries", http.StatusOK, "AddTimeEntry"},
+		{"GET", "/tasks/1/time-entries", http.StatusOK, "GetTimeEntries"},
+		{"DELETE", "/tasks/1/time-entries/2", http.StatusNoContent, "DeleteTimeEntry"},
+		{"GET", "/tasks/1/time", http.StatusOK, "GetTaskTimeTotals"},
+		{"GET", "/projects/1/time", http.StatusOK, "GetProjectTimeTotals"},
+		{"GET", "/tasks/1/subtasks", http.StatusOK, "GetSubtasks"},
+		{"PUT", "/tasks/1/parent", http.StatusOK, "SetParent"},
+		{"GET", "/tasks/1/series", http.StatusOK, "GetTaskSeries"},
+		{"PUT", "/tasks/1/recurrence", http.StatusOK, "SetRecurrence"},
+		{"DELETE", "/tasks/1/recurrence", http.StatusOK, "StopRecurrence"},
+		{"GET", "/tasks/archived", http.StatusOK, "GetArchivedTasks"},
+		{"POST", "/tasks/archive", http.StatusOK, "ArchiveCompletedTasks"},
+		{"PUT", "/tasks/1/fields/2", http.StatusOK, "SetFieldValue"},
+		{"DELETE", "/tasks/1/fields/2", http.StatusOK, "ClearFieldValue"},
+		{"POST", "/tasks/1/move", http.StatusOK, "ReorderTask"},
+		{"PUT", "/tasks/1/archive", http.StatusOK, "ArchiveTask"},
+		{"DELETE", "/tasks/1/archive", http.StatusOK, "UnarchiveTask"},
+		{"POST", "/tasks/1/restore", http.StatusOK, "RestoreTask"},
+		{"GET", "/tasks/1/history", http.StatusOK, "GetTaskHistory"},
+		{"POST", "/tasks/1/history/2/revert", http.StatusOK, "RevertTask"},
 		{"DELETE", "/tasks/1", http.StatusNoContent, "DeleteTask"},
+		{"GET", "/trash", http.StatusOK, "GetTrash"},
+		{"DELETE", "/trash/1", http.StatusNoContent, "PurgeTask"},
 	}
 
 	for _, tt := range tests {
@@ -85,3 +531,281 @@ func TestSetupRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupRouter_TagRoutes(t *testing.T) {
+	// Create a mock tag handler
+	mockTagHandler := new(MockTagHandler)
+	router := SetupRouter(new(MockTaskHandler), mockTagHandler, new(MockProjectHandler), new(MockCommentHandler), new(MockAttachmentHandler), new(MockUserHandler), new(MockReminderHandler), new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/tags", http.StatusOK, "CreateTag"},
+		{"GET", "/tags", http.StatusOK, "GetAllTags"},
+		{"GET", "/tags/1", http.StatusOK, "GetTagByID"},
+		{"PUT", "/tags/1", http.StatusOK, "UpdateTag"},
+		{"DELETE", "/tags/1", http.StatusNoContent, "DeleteTag"},
+		{"POST", "/tasks/1/tags", http.StatusOK, "AddTagToTask"},
+		{"DELETE", "/tasks/1/tags/2", http.StatusOK, "RemoveTagFromTask"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockTagHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockTagHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_ProjectRoutes(t *testing.T) {
+	// Create a mock project handler
+	mockProjectHandler := new(MockProjectHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), mockProjectHandler, new(MockCommentHandler), new(MockAttachmentHandler), new(MockUserHandler), new(MockReminderHandler), new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/projects", http.StatusOK, "CreateProject"},
+		{"GET", "/projects", http.StatusOK, "GetAllProjects"},
+		{"GET", "/projects/1", http.StatusOK, "GetProjectByID"},
+		{"PUT", "/projects/1", http.StatusOK, "UpdateProject"},
+		{"DELETE", "/projects/1", http.StatusNoContent, "DeleteProject"},
+		{"GET", "/projects/1/tasks", http.StatusOK, "GetProjectTasks"},
+		{"PUT", "/tasks/1/project", http.StatusOK, "MoveTaskToProject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockProjectHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockProjectHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_CommentRoutes(t *testing.T) {
+	// Create a mock comment handler
+	mockCommentHandler := new(MockCommentHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), new(MockProjectHandler), mockCommentHandler, new(MockAttachmentHandler), new(MockUserHandler), new(MockReminderHandler), new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/tasks/1/comments", http.StatusOK, "AddComment"},
+		{"GET", "/tasks/1/comments", http.StatusOK, "GetComments"},
+		{"GET", "/tasks/1/comments/2", http.StatusOK, "GetComment"},
+		{"PUT", "/tasks/1/comments/2", http.StatusOK, "UpdateComment"},
+		{"DELETE", "/tasks/1/comments/2", http.StatusNoContent, "DeleteComment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockCommentHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockCommentHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_AttachmentRoutes(t *testing.T) {
+	// Create a mock attachment handler
+	mockAttachmentHandler := new(MockAttachmentHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), new(MockProjectHandler), new(MockCommentHandler), mockAttachmentHandler, new(MockUserHandler), new(MockReminderHandler), new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/tasks/1/attachments", http.StatusOK, "UploadAttachment"},
+		{"GET", "/tasks/1/attachments", http.StatusOK, "GetAttachments"},
+		{"GET", "/tasks/1/attachments/2", http.StatusOK, "DownloadAttachment"},
+		{"DELETE", "/tasks/1/attachments/2", http.StatusNoContent, "DeleteAttachment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockAttachmentHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockAttachmentHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_UserRoutes(t *testing.T) {
+	// Create a mock user handler
+	mockUserHandler := new(MockUserHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), new(MockProjectHandler), new(MockCommentHandler), new(MockAttachmentHandler), mockUserHandler, new(MockReminderHandler), new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/users", http.StatusOK, "CreateUser"},
+		{"GET", "/users", http.StatusOK, "GetAllUsers"},
+		{"GET", "/users/1", http.StatusOK, "GetUserByID"},
+		{"DELETE", "/users/1", http.StatusNoContent, "DeleteUser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockUserHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockUserHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_ReminderRoutes(t *testing.T) {
+	// Create a mock reminder handler
+	mockReminderHandler := new(MockReminderHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), new(MockProjectHandler), new(MockCommentHandler), new(MockAttachmentHandler), new(MockUserHandler), mockReminderHandler, new(MockCustomFieldHandler))
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/tasks/1/reminders", http.StatusOK, "AddReminder"},
+		{"GET", "/tasks/1/reminders", http.StatusOK, "GetReminders"},
+		{"DELETE", "/tasks/1/reminders/2", http.StatusNoContent, "DeleteReminder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockReminderHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockReminderHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}
+
+func TestSetupRouter_CustomFieldRoutes(t *testing.T) {
+	// Create a mock custom field handler
+	mockCustomFieldHandler := new(MockCustomFieldHandler)
+	router := SetupRouter(new(MockTaskHandler), new(MockTagHandler), new(MockProjectHandler), new(MockCommentHandler), new(MockAttachmentHandler), new(MockUserHandler), new(MockReminderHandler), mockCustomFieldHandler)
+
+	// Define test cases
+	tests := []struct {
+		method       string
+		path         string
+		expectedCode int
+		mockMethod   string
+	}{
+		{"POST", "/projects/1/fields", http.StatusOK, "AddField"},
+		{"GET", "/projects/1/fields", http.StatusOK, "GetFields"},
+		{"DELETE", "/projects/1/fields/2", http.StatusNoContent, "DeleteField"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// Expect the mock method to be called
+			mockCustomFieldHandler.On(tt.mockMethod, mock.Anything).Return().Once()
+
+			// Create an HTTP request and response recorder
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			// Serve the request
+			router.ServeHTTP(recorder, req)
+
+			// Assert response code
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			// Assert the mock method was called
+			mockCustomFieldHandler.AssertCalled(t, tt.mockMethod, mock.Anything)
+		})
+	}
+}